@@ -0,0 +1,306 @@
+package gosdk
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const animatePath = "/animate"
+
+// AnimateOptions for the ScreenshotOne.com API animate method, which records videos and GIFs of pages.
+//
+// Like TakeOptions, setters of scalar options replace the previously set value,
+// and the client never modifies options.
+type AnimateOptions struct {
+	query url.Values
+}
+
+// NewAnimateWithURL returns options for the ScreenshotOne.com API animate method.
+func NewAnimateWithURL(pageURL string) *AnimateOptions {
+	query := url.Values{}
+	query.Add("url", pageURL)
+
+	return &AnimateOptions{query: query}
+}
+
+// NewAnimateWithHTML returns options for the ScreenshotOne.com API animate method.
+func NewAnimateWithHTML(html string) *AnimateOptions {
+	query := url.Values{}
+	query.Add("html", html)
+
+	return &AnimateOptions{query: query}
+}
+
+// Clone returns a deep copy of the options which can be modified independently.
+func (o *AnimateOptions) Clone() *AnimateOptions {
+	query := make(url.Values, len(o.query))
+	for key, values := range o.query {
+		query[key] = append([]string(nil), values...)
+	}
+
+	return &AnimateOptions{query: query}
+}
+
+// Scenario sets the animation scenario, e.g. AnimateScenarioScroll.
+func (o *AnimateOptions) Scenario(scenario AnimateScenario) *AnimateOptions {
+	o.query.Set("scenario", string(scenario))
+
+	return o
+}
+
+// Duration sets the duration of the animation (seconds).
+func (o *AnimateOptions) Duration(duration int) *AnimateOptions {
+	o.query.Set("duration", strconv.Itoa(duration))
+
+	return o
+}
+
+// Format sets the format of the animation, one of: FormatMP4, FormatWebM, FormatMOV, FormatAVI or FormatGIF.
+func (o *AnimateOptions) Format(format Format) *AnimateOptions {
+	o.query.Set("format", string(format))
+
+	return o
+}
+
+// Width sets the width of the resulting video (pixels).
+func (o *AnimateOptions) Width(width int) *AnimateOptions {
+	o.query.Set("width", strconv.Itoa(width))
+
+	return o
+}
+
+// Height sets the height of the resulting video (pixels).
+func (o *AnimateOptions) Height(height int) *AnimateOptions {
+	o.query.Set("height", strconv.Itoa(height))
+
+	return o
+}
+
+// AspectRatio sets the aspect ratio of the resulting video, e.g. "16:9" or "4:3".
+func (o *AnimateOptions) AspectRatio(aspectRatio string) *AnimateOptions {
+	o.query.Set("aspect_ratio", aspectRatio)
+
+	return o
+}
+
+// ScrollDelay sets the delay between scrolls (milliseconds).
+func (o *AnimateOptions) ScrollDelay(delay int) *AnimateOptions {
+	o.query.Set("scroll_delay", strconv.Itoa(delay))
+
+	return o
+}
+
+// ScrollDuration sets the duration of a single scroll, which controls the scroll speed (milliseconds).
+func (o *AnimateOptions) ScrollDuration(duration int) *AnimateOptions {
+	o.query.Set("scroll_duration", strconv.Itoa(duration))
+
+	return o
+}
+
+// ScrollBy sets how much to scroll by at once (pixels).
+func (o *AnimateOptions) ScrollBy(pixels int) *AnimateOptions {
+	o.query.Set("scroll_by", strconv.Itoa(pixels))
+
+	return o
+}
+
+// ScrollEasing sets the easing function of scrolling, e.g. ScrollEasingEaseInOutQuint.
+func (o *AnimateOptions) ScrollEasing(easing ScrollEasing) *AnimateOptions {
+	o.query.Set("scroll_easing", string(easing))
+
+	return o
+}
+
+// ScrollStartImmediately controls whether to start scrolling immediately after the page is loaded.
+func (o *AnimateOptions) ScrollStartImmediately(immediately bool) *AnimateOptions {
+	o.query.Set("scroll_start_immediately", strconv.FormatBool(immediately))
+
+	return o
+}
+
+// ScrollBack controls whether to scroll back to the top after reaching the bottom.
+func (o *AnimateOptions) ScrollBack(scrollBack bool) *AnimateOptions {
+	o.query.Set("scroll_back", strconv.FormatBool(scrollBack))
+
+	return o
+}
+
+// ScrollComplete controls whether to stop recording when scrolling is complete.
+func (o *AnimateOptions) ScrollComplete(complete bool) *AnimateOptions {
+	o.query.Set("scroll_complete", strconv.FormatBool(complete))
+
+	return o
+}
+
+// ViewportWidth sets the width of the browser viewport (pixels).
+func (o *AnimateOptions) ViewportWidth(viewportWidth int) *AnimateOptions {
+	o.query.Set("viewport_width", strconv.Itoa(viewportWidth))
+
+	return o
+}
+
+// ViewportHeight sets the height of the browser viewport (pixels).
+func (o *AnimateOptions) ViewportHeight(viewportHeight int) *AnimateOptions {
+	o.query.Set("viewport_height", strconv.Itoa(viewportHeight))
+
+	return o
+}
+
+// DeviceScaleFactor sets the device scale factor.
+func (o *AnimateOptions) DeviceScaleFactor(deviceScaleFactor int) *AnimateOptions {
+	o.query.Set("device_scale_factor", strconv.Itoa(deviceScaleFactor))
+
+	return o
+}
+
+// BlockAds blocks ads.
+func (o *AnimateOptions) BlockAds(blockAds bool) *AnimateOptions {
+	o.query.Set("block_ads", strconv.FormatBool(blockAds))
+
+	return o
+}
+
+// BlockTrackers blocks trackers.
+func (o *AnimateOptions) BlockTrackers(blockTrackers bool) *AnimateOptions {
+	o.query.Set("block_trackers", strconv.FormatBool(blockTrackers))
+
+	return o
+}
+
+// BlockCookieBanners blocks cookie banners and privacy notices.
+func (o *AnimateOptions) BlockCookieBanners(block bool) *AnimateOptions {
+	o.query.Set("block_cookie_banners", strconv.FormatBool(block))
+
+	return o
+}
+
+// DarkMode sets the dark mode for the animation.
+func (o *AnimateOptions) DarkMode(enabled bool) *AnimateOptions {
+	o.query.Set("dark_mode", strconv.FormatBool(enabled))
+
+	return o
+}
+
+// Delay sets delay before recording (seconds).
+func (o *AnimateOptions) Delay(delay int) *AnimateOptions {
+	o.query.Set("delay", strconv.Itoa(delay))
+
+	return o
+}
+
+// Timeout sets timeout (seconds).
+func (o *AnimateOptions) Timeout(timeout int) *AnimateOptions {
+	o.query.Set("timeout", strconv.Itoa(timeout))
+
+	return o
+}
+
+// Validate checks the options for invalid values, unknown enum values
+// and mutually exclusive options without making any request.
+// It returns ValidationErrors or nil if the options are valid.
+func (o *AnimateOptions) Validate() error {
+	return validateAnimateQuery(o.query)
+}
+
+var animateBooleanOptions = []string{
+	"scroll_start_immediately", "scroll_back", "scroll_complete", "block_ads", "block_trackers",
+	"block_cookie_banners", "dark_mode",
+}
+
+var animateIntegerRanges = []integerRange{
+	{"duration", 1, math.MaxInt32},
+	{"width", 1, 1 << 16},
+	{"height", 1, 1 << 16},
+	{"scroll_delay", 0, math.MaxInt32},
+	{"scroll_duration", 0, math.MaxInt32},
+	{"scroll_by", 1, math.MaxInt32},
+	{"viewport_width", 1, 1 << 16},
+	{"viewport_height", 1, 1 << 16},
+	{"device_scale_factor", 1, 3},
+	{"delay", 0, math.MaxInt32},
+	{"timeout", 1, math.MaxInt32},
+}
+
+var animateEnumOptions = []enumOption{
+	{"format", animateFormatValues},
+	{"scenario", animateScenarioValues},
+	{"scroll_easing", scrollEasingValues},
+}
+
+// animateDefaultOptions are the options of the take method the animate method supports,
+// only they are taken from the default options of the client.
+var animateDefaultOptions = map[string]bool{
+	"viewport_width": true, "viewport_height": true, "device_scale_factor": true, "block_ads": true,
+	"block_trackers": true, "block_cookie_banners": true, "dark_mode": true, "delay": true, "timeout": true,
+}
+
+// validateAnimateQuery validates the animate query and returns ValidationErrors or nil.
+func validateAnimateQuery(query url.Values) error {
+	v := newValidator(query)
+	v.sources("url", "html")
+	v.scalars()
+	v.booleans(animateBooleanOptions)
+	v.integers(animateIntegerRanges)
+	v.enums(animateEnumOptions)
+
+	return v.err()
+}
+
+// animateQuery returns a copy of the animate query with the default options and the access key applied,
+// validated if the client validates options.
+func (client *Client) animateQuery(options *AnimateOptions) (url.Values, error) {
+	query := options.Clone().query
+	if client.defaultOptions != nil {
+		for key, values := range client.defaultOptions.query {
+			if _, ok := query[key]; !ok && animateDefaultOptions[key] {
+				query[key] = append([]string(nil), values...)
+			}
+		}
+	}
+	if client.validate {
+		if err := validateAnimateQuery(query); err != nil {
+			return nil, err
+		}
+	}
+	query.Set("access_key", client.accessKey)
+
+	return query, nil
+}
+
+// GenerateAnimateURL generates URL for recording animated screenshots with request signing.
+// The default options of the client supported by the animate method, like the viewport and blocking options, are applied.
+func (client *Client) GenerateAnimateURL(options *AnimateOptions) (*url.URL, error) {
+	query, err := client.animateQuery(options)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.signedURL(animatePath, query)
+}
+
+// Animate records the animated screenshot and writes it to w without buffering the whole video in memory.
+// The Body of the returned result is nil.
+func (client *Client) Animate(ctx context.Context, w io.Writer, options *AnimateOptions) (*TakeResult, error) {
+	u, err := client.GenerateAnimateURL(options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate URL: %w", err)
+	}
+
+	response, attempts, err := client.execute(ctx, &apiRequest{method: http.MethodGet, url: u})
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	_, err = io.Copy(w, response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write the video data from HTTP response: %w", err)
+	}
+
+	return newTakeResult(response, nil, attempts), nil
+}
@@ -0,0 +1,99 @@
+package gosdk_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	screenshots "github.com/screenshotone/gosdk"
+)
+
+func TestGenerateAnimateURL(t *testing.T) {
+	client, err := screenshots.NewClient("IVmt2ghj9TG_jQ", "Sxt94yAj9aQSgg")
+	ok(t, err)
+
+	options := screenshots.NewAnimateWithURL("https://example.com").
+		Scenario(screenshots.AnimateScenarioScroll).
+		Format(screenshots.FormatMP4).
+		Duration(5).
+		ScrollEasing(screenshots.ScrollEasingEaseInOutQuint).
+		ScrollDelay(500).
+		AspectRatio("16:9")
+
+	u, err := client.GenerateAnimateURL(options)
+	ok(t, err)
+
+	equals(t, "/animate", u.Path)
+	equals(t, "access_key=IVmt2ghj9TG_jQ&aspect_ratio=16%3A9&duration=5&format=mp4&scenario=scroll&scroll_delay=500&scroll_easing=ease_in_out_quint&url=https%3A%2F%2Fexample.com", u.RawQuery[:len(u.RawQuery)-len("&signature=")-64])
+	ok(t, screenshots.VerifySignature("Sxt94yAj9aQSgg", u.RawQuery))
+}
+
+func TestGenerateAnimateURLAppliesDefaultOptions(t *testing.T) {
+	client, err := screenshots.NewClient("IVmt2ghj9TG_jQ", "Sxt94yAj9aQSgg",
+		screenshots.WithDefaultOptions(screenshots.NewTakeDefaults().BlockAds(true).ViewportWidth(800).Format(screenshots.FormatPNG)),
+	)
+	ok(t, err)
+
+	options := screenshots.NewAnimateWithURL("https://example.com").ViewportWidth(1024)
+
+	u, err := client.GenerateAnimateURL(options)
+	ok(t, err)
+
+	query := u.Query()
+	equals(t, "true", query.Get("block_ads"))
+	equals(t, "1024", query.Get("viewport_width"))
+	equals(t, []string(nil), query["format"])
+
+	u, err = client.GenerateAnimateURL(options)
+	ok(t, err)
+	equals(t, "1024", u.Query().Get("viewport_width"))
+}
+
+func TestAnimateValidation(t *testing.T) {
+	ok(t, screenshots.NewAnimateWithURL("https://example.com").Format(screenshots.FormatMP4).Scenario(screenshots.AnimateScenarioScroll).Validate())
+
+	err := screenshots.NewAnimateWithURL("https://example.com").Format(screenshots.FormatPNG).Duration(0).Validate()
+	errorred(t, err, "duration: must be between 1 and 2147483647, got 0")
+	errorred(t, err, "format: must be one of [\"mp4\" \"webm\" \"mov\" \"avi\" \"gif\"], got \"png\"")
+
+	roundTripper := &mockRoundTripper{statusCode: http.StatusOK}
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: roundTripper},
+		screenshots.WithValidation(),
+	)
+	ok(t, err)
+
+	_, err = client.GenerateAnimateURL(screenshots.NewAnimateWithURL("example.com"))
+	errorred(t, err, "invalid options: url: must be an absolute URL")
+
+	var out bytes.Buffer
+	_, err = client.Animate(context.Background(), &out, screenshots.NewAnimateWithHTML("<h1>Hello</h1>").Format(screenshots.FormatJPG))
+	errorred(t, err, "format: must be one of")
+	equals(t, (*http.Request)(nil), roundTripper.request)
+}
+
+func TestAnimateWritesVideo(t *testing.T) {
+	header := make(http.Header)
+	header.Set("Content-Type", "video/webm")
+
+	roundTripper := &mockRoundTripper{statusCode: http.StatusOK, body: []byte("test video data"), header: header}
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: roundTripper})
+	ok(t, err)
+
+	var out bytes.Buffer
+	result, err := client.Animate(context.Background(), &out, screenshots.NewAnimateWithURL("https://example.com").Format(screenshots.FormatWebM))
+	ok(t, err)
+
+	equals(t, "/animate", roundTripper.request.URL.Path)
+	equals(t, "test video data", out.String())
+	equals(t, "webm", result.Format)
+}
+
+func TestAnimateRejectsOtherStatusCodes(t *testing.T) {
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: &mockRoundTripper{statusCode: http.StatusBadRequest}})
+	ok(t, err)
+
+	var out bytes.Buffer
+	_, err = client.Animate(context.Background(), &out, screenshots.NewAnimateWithURL("https://example.com"))
+	errorred(t, err, "the server returned a response: 400 Bad Request")
+}
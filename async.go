@@ -0,0 +1,196 @@
+package gosdk
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	"github.com/screenshotone/gosdk/webhook"
+)
+
+// AsyncDispatcher correlates webhook events with the jobs started by Client.TakeAsync
+// by the external identifier of the request.
+//
+// Use Dispatch as the success and error callbacks of the webhook handler:
+//
+//	dispatcher := screenshots.NewAsyncDispatcher()
+//	handler, err := webhook.NewHandler(secretKey, webhook.OnSuccess(dispatcher.Dispatch), webhook.OnError(dispatcher.Dispatch))
+type AsyncDispatcher struct {
+	mu   sync.Mutex
+	jobs map[string]*AsyncJob
+}
+
+// NewAsyncDispatcher returns a new dispatcher without pending jobs.
+func NewAsyncDispatcher() *AsyncDispatcher {
+	return &AsyncDispatcher{jobs: make(map[string]*AsyncJob)}
+}
+
+// WithAsyncDispatcher sets the dispatcher resolving the jobs started by TakeAsync.
+func WithAsyncDispatcher(dispatcher *AsyncDispatcher) ClientOption {
+	return func(client *Client) error {
+		if dispatcher == nil {
+			return fmt.Errorf("dispatcher is required")
+		}
+
+		client.asyncDispatcher = dispatcher
+
+		return nil
+	}
+}
+
+// Dispatch resolves the pending job matching the external identifier of the event.
+// Events without a matching job are ignored.
+func (d *AsyncDispatcher) Dispatch(ctx context.Context, event *webhook.Event) error {
+	d.mu.Lock()
+	job, ok := d.jobs[event.ExternalIdentifier]
+	if ok {
+		delete(d.jobs, event.ExternalIdentifier)
+	}
+	d.mu.Unlock()
+
+	if ok {
+		job.resolve(event)
+	}
+
+	return nil
+}
+
+// Pending returns the number of jobs waiting for webhook events.
+func (d *AsyncDispatcher) Pending() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return len(d.jobs)
+}
+
+func (d *AsyncDispatcher) register(job *AsyncJob) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, ok := d.jobs[job.id]; ok {
+		return fmt.Errorf("job with the external identifier \"%s\" is already pending", job.id)
+	}
+	d.jobs[job.id] = job
+
+	return nil
+}
+
+func (d *AsyncDispatcher) unregister(job *AsyncJob) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.jobs[job.id] == job {
+		delete(d.jobs, job.id)
+	}
+}
+
+// AsyncJob is a screenshot rendered in the background, resolved when the webhook event arrives.
+type AsyncJob struct {
+	id         string
+	dispatcher *AsyncDispatcher
+
+	done  chan struct{}
+	once  sync.Once
+	event *webhook.Event
+}
+
+// ExternalIdentifier returns the external identifier the job is correlated by.
+func (job *AsyncJob) ExternalIdentifier() string {
+	return job.id
+}
+
+// Done returns a channel closed when the webhook event arrives.
+func (job *AsyncJob) Done() <-chan struct{} {
+	return job.done
+}
+
+// Wait waits for the webhook event or until the context is done, in which case the job is canceled.
+// For failed requests, it returns the event along with an *APIError describing the failure.
+func (job *AsyncJob) Wait(ctx context.Context) (*webhook.Event, error) {
+	select {
+	case <-ctx.Done():
+		job.Cancel()
+
+		return nil, ctx.Err()
+	case <-job.done:
+	}
+
+	if !job.event.IsSuccessful {
+		return job.event, &APIError{
+			Code:             job.event.ErrorCode,
+			Message:          job.event.ErrorMessage,
+			DocumentationURL: job.event.DocumentationURL,
+			Body:             job.event.Raw,
+		}
+	}
+
+	return job.event, nil
+}
+
+// Cancel stops waiting for the webhook event, the event is ignored when it arrives.
+// It does not cancel rendering.
+func (job *AsyncJob) Cancel() {
+	job.dispatcher.unregister(job)
+}
+
+func (job *AsyncJob) resolve(event *webhook.Event) {
+	job.once.Do(func() {
+		job.event = event
+		close(job.done)
+	})
+}
+
+// TakeAsync starts rendering the screenshot in the background and returns the job resolved
+// when the webhook event with the same external identifier arrives to the dispatcher of the client.
+// The options or the default options of the client must have the WebhookURL set,
+// and WebhookErrors to be notified about failures.
+// A random external identifier is used if the options do not have it.
+func (client *Client) TakeAsync(ctx context.Context, options *TakeOptions) (*AsyncJob, error) {
+	if client.asyncDispatcher == nil {
+		return nil, fmt.Errorf("async dispatcher is required, see WithAsyncDispatcher")
+	}
+	if client.query(options).Get("webhook_url") == "" {
+		return nil, fmt.Errorf("webhook URL is required, see TakeOptions.WebhookURL")
+	}
+
+	options = options.Clone().Async(true)
+	if !options.Has("external_identifier") {
+		id, err := randomIdentifier()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate external identifier: %w", err)
+		}
+		options.ExternalIdentifier(id)
+	}
+
+	job := &AsyncJob{
+		id:         options.Get("external_identifier"),
+		dispatcher: client.asyncDispatcher,
+		done:       make(chan struct{}),
+	}
+
+	// register the job before the request, so the webhook can't arrive before it
+	if err := client.asyncDispatcher.register(job); err != nil {
+		return nil, err
+	}
+
+	response, _, err := client.take(ctx, options)
+	if err != nil {
+		job.Cancel()
+
+		return nil, err
+	}
+	response.Body.Close()
+
+	return job, nil
+}
+
+func randomIdentifier() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
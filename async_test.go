@@ -0,0 +1,133 @@
+package gosdk_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	screenshots "github.com/screenshotone/gosdk"
+	"github.com/screenshotone/gosdk/webhook"
+)
+
+func TestTakeAsyncResolvesOnWebhook(t *testing.T) {
+	roundTripper := &mockRoundTripper{statusCode: http.StatusOK}
+	dispatcher := screenshots.NewAsyncDispatcher()
+
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: roundTripper},
+		screenshots.WithAsyncDispatcher(dispatcher),
+	)
+	ok(t, err)
+
+	options := screenshots.NewTakeOptions("https://example.com").WebhookURL("https://example.com/webhook").ExternalIdentifier("job-1")
+	job, err := client.TakeAsync(context.Background(), options)
+	ok(t, err)
+
+	equals(t, "job-1", job.ExternalIdentifier())
+	equals(t, "true", roundTripper.request.URL.Query().Get("async"))
+	equals(t, false, options.Has("async"))
+	equals(t, 1, dispatcher.Pending())
+
+	go func() {
+		_ = dispatcher.Dispatch(context.Background(), &webhook.Event{IsSuccessful: true, ExternalIdentifier: "other-job"})
+		_ = dispatcher.Dispatch(context.Background(), &webhook.Event{IsSuccessful: true, ExternalIdentifier: "job-1", ScreenshotURL: "https://cdn.example.com/job-1.png"})
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	event, err := job.Wait(ctx)
+	ok(t, err)
+
+	equals(t, "https://cdn.example.com/job-1.png", event.ScreenshotURL)
+	equals(t, 0, dispatcher.Pending())
+}
+
+func TestTakeAsyncReturnsWebhookErrors(t *testing.T) {
+	dispatcher := screenshots.NewAsyncDispatcher()
+
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: &mockRoundTripper{statusCode: http.StatusOK}},
+		screenshots.WithAsyncDispatcher(dispatcher),
+	)
+	ok(t, err)
+
+	job, err := client.TakeAsync(context.Background(), screenshots.NewTakeOptions("https://example.com").WebhookURL("https://example.com/webhook").WebhookErrors(true))
+	ok(t, err)
+	equals(t, 32, len(job.ExternalIdentifier()))
+
+	ok(t, dispatcher.Dispatch(context.Background(), &webhook.Event{ExternalIdentifier: job.ExternalIdentifier(), ErrorCode: "selector_not_found"}))
+
+	_, err = job.Wait(context.Background())
+	equals(t, true, errors.Is(err, screenshots.ErrSelectorNotFound))
+}
+
+func TestTakeAsyncStopsWaitingWhenContextIsDone(t *testing.T) {
+	dispatcher := screenshots.NewAsyncDispatcher()
+
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: &mockRoundTripper{statusCode: http.StatusOK}},
+		screenshots.WithAsyncDispatcher(dispatcher),
+	)
+	ok(t, err)
+
+	job, err := client.TakeAsync(context.Background(), screenshots.NewTakeOptions("https://example.com").WebhookURL("https://example.com/webhook").ExternalIdentifier("job-1"))
+	ok(t, err)
+	equals(t, 1, dispatcher.Pending())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = job.Wait(ctx)
+	equals(t, context.DeadlineExceeded, err)
+	equals(t, 0, dispatcher.Pending())
+}
+
+func TestTakeAsyncRequiresWebhookURL(t *testing.T) {
+	roundTripper := &mockRoundTripper{statusCode: http.StatusOK}
+	dispatcher := screenshots.NewAsyncDispatcher()
+
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: roundTripper},
+		screenshots.WithAsyncDispatcher(dispatcher),
+	)
+	ok(t, err)
+
+	_, err = client.TakeAsync(context.Background(), screenshots.NewTakeOptions("https://example.com"))
+	errorred(t, err, "webhook URL is required")
+	equals(t, (*http.Request)(nil), roundTripper.request)
+	equals(t, 0, dispatcher.Pending())
+}
+
+func TestTakeAsyncUsesDefaultWebhookURL(t *testing.T) {
+	roundTripper := &mockRoundTripper{statusCode: http.StatusOK}
+
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: roundTripper},
+		screenshots.WithAsyncDispatcher(screenshots.NewAsyncDispatcher()),
+		screenshots.WithDefaultOptions(screenshots.NewTakeDefaults().WebhookURL("https://example.com/webhook")),
+	)
+	ok(t, err)
+
+	_, err = client.TakeAsync(context.Background(), screenshots.NewTakeOptions("https://example.com"))
+	ok(t, err)
+	equals(t, "https://example.com/webhook", roundTripper.request.URL.Query().Get("webhook_url"))
+}
+
+func TestTakeAsyncUnregistersFailedRequests(t *testing.T) {
+	dispatcher := screenshots.NewAsyncDispatcher()
+
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: &mockRoundTripper{statusCode: http.StatusBadRequest}},
+		screenshots.WithAsyncDispatcher(dispatcher),
+	)
+	ok(t, err)
+
+	_, err = client.TakeAsync(context.Background(), screenshots.NewTakeOptions("https://example.com").WebhookURL("https://example.com/webhook").ExternalIdentifier("job-1"))
+	errorred(t, err, "the server returned a response: 400")
+	equals(t, 0, dispatcher.Pending())
+}
+
+func TestTakeAsyncRequiresDispatcher(t *testing.T) {
+	client, err := screenshots.NewClient("test-key", "test-secret")
+	ok(t, err)
+
+	_, err = client.TakeAsync(context.Background(), screenshots.NewTakeOptions("https://example.com"))
+	errorred(t, err, "async dispatcher is required")
+}
@@ -0,0 +1,210 @@
+package gosdk
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Batch takes screenshots for many options with a bounded number of requests in flight.
+// Set the concurrency to the concurrency limit of the plan, see Client.Usage.
+//
+// A batch can be run many times and from many goroutines.
+type Batch struct {
+	client      *Client
+	concurrency int
+	ordered     bool
+	progress    func(BatchProgress)
+}
+
+// BatchOption configures the batch.
+type BatchOption func(*Batch) error
+
+// BatchResult is the result of a single screenshot taken by the batch.
+type BatchResult struct {
+	// Index is the position of the options in the input.
+	Index int
+	// Options are the options the screenshot was taken with.
+	Options *TakeOptions
+	// Result is the screenshot, nil if the request failed.
+	Result *TakeResult
+	// Err is the error of the request.
+	Err error
+}
+
+// BatchProgress describes the progress of a running batch.
+type BatchProgress struct {
+	// Completed is the number of finished requests, including the failed ones.
+	Completed int
+	// Failed is the number of failed requests.
+	Failed int
+	// Total is the number of requests in the batch, -1 if the options are read from a channel.
+	Total int
+}
+
+// NewBatch returns a batch taking screenshots with the client, one at a time by default.
+func NewBatch(client *Client, opts ...BatchOption) (*Batch, error) {
+	if client == nil {
+		return nil, fmt.Errorf("client is required")
+	}
+
+	batch := &Batch{client: client, concurrency: 1}
+	for _, opt := range opts {
+		if err := opt(batch); err != nil {
+			return nil, err
+		}
+	}
+
+	return batch, nil
+}
+
+// WithBatchConcurrency sets the maximum number of requests in flight.
+func WithBatchConcurrency(concurrency int) BatchOption {
+	return func(batch *Batch) error {
+		if concurrency < 1 {
+			return fmt.Errorf("concurrency must be positive, got %d", concurrency)
+		}
+
+		batch.concurrency = concurrency
+
+		return nil
+	}
+}
+
+// WithBatchOrdered makes the batch yield results in the order of the input instead of as they complete.
+// Results completed ahead of a slow request are held in memory until it completes.
+func WithBatchOrdered() BatchOption {
+	return func(batch *Batch) error {
+		batch.ordered = true
+
+		return nil
+	}
+}
+
+// WithBatchProgress sets the callback called after every completed request, before its result is yielded.
+// Calls are never concurrent.
+func WithBatchProgress(progress func(BatchProgress)) BatchOption {
+	return func(batch *Batch) error {
+		batch.progress = progress
+
+		return nil
+	}
+}
+
+type batchItem struct {
+	index   int
+	options *TakeOptions
+}
+
+// Run takes screenshots for all the options and streams the results.
+// The channel is closed when all the requests are done. Once the context is done,
+// the remaining requests fail with the context error without being sent.
+// The caller must read all the results from the channel.
+func (b *Batch) Run(ctx context.Context, options []*TakeOptions) <-chan *BatchResult {
+	items := make(chan batchItem)
+	go func() {
+		defer close(items)
+
+		for index, o := range options {
+			items <- batchItem{index: index, options: o}
+		}
+	}()
+
+	return b.run(ctx, items, len(options))
+}
+
+// RunChannel takes screenshots for the options read from the channel until it is closed
+// or the context is done, and streams the results.
+// The results channel is closed when all the read options are done.
+// The caller must read all the results from the channel.
+func (b *Batch) RunChannel(ctx context.Context, options <-chan *TakeOptions) <-chan *BatchResult {
+	items := make(chan batchItem)
+	go func() {
+		defer close(items)
+
+		for index := 0; ; index++ {
+			select {
+			case <-ctx.Done():
+				return
+			case o, ok := <-options:
+				if !ok {
+					return
+				}
+				items <- batchItem{index: index, options: o}
+			}
+		}
+	}()
+
+	return b.run(ctx, items, -1)
+}
+
+func (b *Batch) run(ctx context.Context, items <-chan batchItem, total int) <-chan *BatchResult {
+	completed := make(chan *BatchResult)
+
+	var wg sync.WaitGroup
+	for i := 0; i < b.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for item := range items {
+				completed <- b.take(ctx, item)
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(completed)
+	}()
+
+	results := make(chan *BatchResult)
+	go func() {
+		defer close(results)
+
+		progress := BatchProgress{Total: total}
+		pending := make(map[int]*BatchResult)
+		next := 0
+		for result := range completed {
+			progress.Completed++
+			if result.Err != nil {
+				progress.Failed++
+			}
+			if b.progress != nil {
+				b.progress(progress)
+			}
+
+			if !b.ordered {
+				results <- result
+
+				continue
+			}
+
+			pending[result.Index] = result
+			for {
+				result, ok := pending[next]
+				if !ok {
+					break
+				}
+				delete(pending, next)
+				next++
+				results <- result
+			}
+		}
+	}()
+
+	return results
+}
+
+func (b *Batch) take(ctx context.Context, item batchItem) *BatchResult {
+	result := &BatchResult{Index: item.index, Options: item.options}
+	if err := ctx.Err(); err != nil {
+		result.Err = err
+
+		return result
+	}
+
+	result.Result, result.Err = b.client.Take(ctx, item.options)
+
+	return result
+}
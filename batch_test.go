@@ -0,0 +1,162 @@
+package gosdk_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	screenshots "github.com/screenshotone/gosdk"
+)
+
+var batchURLs = []string{"https://slow.example.com", "https://example.org", "https://example.net", "https://example.io"}
+
+func TestBatchRunYieldsResultsInOrder(t *testing.T) {
+	roundTripper := &gatedRoundTripper{gate: make(chan struct{})}
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: roundTripper})
+	ok(t, err)
+
+	var progress []screenshots.BatchProgress
+	batch, err := screenshots.NewBatch(client,
+		screenshots.WithBatchConcurrency(2),
+		screenshots.WithBatchOrdered(),
+		screenshots.WithBatchProgress(func(p screenshots.BatchProgress) {
+			progress = append(progress, p)
+			// the slow request completes only after all the others
+			if p.Completed == len(batchURLs)-1 {
+				close(roundTripper.gate)
+			}
+		}),
+	)
+	ok(t, err)
+
+	options := make([]*screenshots.TakeOptions, 0, len(batchURLs))
+	for _, u := range batchURLs {
+		options = append(options, screenshots.NewTakeOptions(u).Format("png"))
+	}
+
+	var results []*screenshots.BatchResult
+	for result := range batch.Run(context.Background(), options) {
+		results = append(results, result)
+	}
+
+	equals(t, len(batchURLs), len(results))
+	for i, u := range batchURLs {
+		ok(t, results[i].Err)
+		equals(t, i, results[i].Index)
+		equals(t, options[i], results[i].Options)
+		equals(t, u+" png", string(results[i].Result.Body))
+	}
+	equals(t, len(batchURLs), len(progress))
+	equals(t, screenshots.BatchProgress{Completed: 4, Failed: 0, Total: 4}, progress[len(progress)-1])
+}
+
+func TestBatchRunChannelYieldsResultsAsCompleted(t *testing.T) {
+	roundTripper := &gatedRoundTripper{gate: make(chan struct{})}
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: roundTripper})
+	ok(t, err)
+
+	batch, err := screenshots.NewBatch(client, screenshots.WithBatchConcurrency(2))
+	ok(t, err)
+
+	options := make(chan *screenshots.TakeOptions)
+	go func() {
+		defer close(options)
+
+		for _, u := range batchURLs {
+			options <- screenshots.NewTakeOptions(u).Format("png")
+		}
+	}()
+
+	var results []*screenshots.BatchResult
+	for result := range batch.RunChannel(context.Background(), options) {
+		ok(t, result.Err)
+		equals(t, batchURLs[result.Index]+" png", string(result.Result.Body))
+		results = append(results, result)
+
+		// the slow request completes only after all the others are yielded
+		if len(results) == len(batchURLs)-1 {
+			close(roundTripper.gate)
+		}
+	}
+
+	equals(t, len(batchURLs), len(results))
+	equals(t, 0, results[len(results)-1].Index)
+}
+
+func TestBatchRunReportsPerItemErrors(t *testing.T) {
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: &mockRoundTripper{
+		statusCode: http.StatusBadRequest,
+		body:       []byte(`{"is_successful":false,"error_code":"selector_not_found"}`),
+	}})
+	ok(t, err)
+
+	var last screenshots.BatchProgress
+	batch, err := screenshots.NewBatch(client, screenshots.WithBatchProgress(func(p screenshots.BatchProgress) { last = p }))
+	ok(t, err)
+
+	for result := range batch.Run(context.Background(), []*screenshots.TakeOptions{
+		screenshots.NewTakeOptions("https://example.com"),
+		screenshots.NewTakeOptions("https://example.org"),
+	}) {
+		errorred(t, result.Err, "selector_not_found")
+	}
+	equals(t, screenshots.BatchProgress{Completed: 2, Failed: 2, Total: 2}, last)
+}
+
+func TestBatchRunStopsWhenContextIsDone(t *testing.T) {
+	roundTripper := &countingRoundTripper{}
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: roundTripper})
+	ok(t, err)
+
+	batch, err := screenshots.NewBatch(client, screenshots.WithBatchConcurrency(2))
+	ok(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	count := 0
+	for result := range batch.Run(ctx, []*screenshots.TakeOptions{
+		screenshots.NewTakeOptions("https://example.com"),
+		screenshots.NewTakeOptions("https://example.org"),
+	}) {
+		equals(t, context.Canceled, result.Err)
+		count++
+	}
+	equals(t, 2, count)
+	equals(t, int32(0), atomic.LoadInt32(&roundTripper.calls))
+}
+
+func TestNewBatchRejectsInvalidConcurrency(t *testing.T) {
+	client, err := screenshots.NewClient("test-key", "test-secret")
+	ok(t, err)
+
+	_, err = screenshots.NewBatch(client, screenshots.WithBatchConcurrency(0))
+	errorred(t, err, "concurrency must be positive, got 0")
+}
+
+// gatedRoundTripper echoes the request like urlEchoRoundTripper, but holds requests to slow hosts
+// until the gate is closed.
+type gatedRoundTripper struct {
+	gate chan struct{}
+}
+
+func (m *gatedRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if strings.Contains(req.URL.Query().Get("url"), "slow") {
+		<-m.gate
+	}
+
+	return (&urlEchoRoundTripper{}).RoundTrip(req)
+}
+
+// countingRoundTripper counts the requests, it is safe for concurrent use.
+type countingRoundTripper struct {
+	calls int32
+}
+
+func (m *countingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	atomic.AddInt32(&m.calls, 1)
+
+	return (&urlEchoRoundTripper{}).RoundTrip(req)
+}
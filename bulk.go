@@ -0,0 +1,198 @@
+package gosdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+const bulkPath = "/bulk"
+
+// BulkOptions for the ScreenshotOne.com API bulk method.
+// They combine options shared by all the requests with per-request options.
+type BulkOptions struct {
+	defaults *TakeOptions
+	requests []*TakeOptions
+	execute  bool
+}
+
+// NewBulkOptions returns bulk options with the options shared by all the requests.
+// The defaults can be nil, per-request options take precedence over them.
+// The defaults are copied, changing them later does not affect the bulk.
+func NewBulkOptions(defaults *TakeOptions) *BulkOptions {
+	if defaults == nil {
+		defaults = NewTakeDefaults()
+	}
+
+	return &BulkOptions{defaults: defaults.Clone()}
+}
+
+// Add adds copies of the requests to the bulk.
+func (o *BulkOptions) Add(requests ...*TakeOptions) *BulkOptions {
+	for _, request := range requests {
+		o.requests = append(o.requests, request.Clone())
+	}
+
+	return o
+}
+
+// Execute makes the API render the screenshots while handling the bulk request
+// instead of only generating the signed URLs.
+func (o *BulkOptions) Execute(execute bool) *BulkOptions {
+	o.execute = execute
+
+	return o
+}
+
+// Len returns the number of requests in the bulk.
+func (o *BulkOptions) Len() int {
+	return len(o.requests)
+}
+
+// Options returns the options of the request with the index merged with the shared options.
+func (o *BulkOptions) Options(index int) *TakeOptions {
+	return &TakeOptions{query: mergeQuery(o.defaults.query, o.requests[index].query)}
+}
+
+// BulkItem is the result of a single request of the bulk method.
+type BulkItem struct {
+	// URL is the signed URL to take the screenshot.
+	URL string `json:"url"`
+	// Response describes the rendering result when the bulk is executed.
+	Response *BulkItemResponse `json:"response,omitempty"`
+}
+
+// BulkItemResponse is the rendering result of a single request of an executed bulk.
+type BulkItemResponse struct {
+	IsSuccessful     bool   `json:"is_successful"`
+	Status           int    `json:"status"`
+	StatusText       string `json:"statusText"`
+	ErrorCode        string `json:"error_code,omitempty"`
+	ErrorMessage     string `json:"error_message,omitempty"`
+	DocumentationURL string `json:"documentation_url,omitempty"`
+}
+
+// Bulk sends the requests to the bulk method and returns an item for every request in the same order.
+// When the bulk is executed, it returns after all the screenshots are rendered, results are not streamed.
+func (client *Client) Bulk(ctx context.Context, options *BulkOptions) ([]*BulkItem, error) {
+	if options.Len() == 0 {
+		return nil, fmt.Errorf("at least one request is required")
+	}
+
+	var defaults url.Values
+	if client.defaultOptions != nil {
+		defaults = client.defaultOptions.query
+	}
+	shared := mergeQuery(defaults, options.defaults.query)
+
+	requests := make([]map[string]interface{}, 0, options.Len())
+	for index, request := range options.requests {
+		if client.validate {
+			if err := validateQuery(mergeQuery(shared, request.query)); err != nil {
+				return nil, fmt.Errorf("request %d: %w", index, err)
+			}
+		}
+		requests = append(requests, jsonObject(request.query))
+	}
+
+	body, err := json.Marshal(struct {
+		AccessKey string                   `json:"access_key"`
+		Execute   bool                     `json:"execute"`
+		Options   map[string]interface{}   `json:"options"`
+		Requests  []map[string]interface{} `json:"requests"`
+	}{client.accessKey, options.execute, jsonObject(shared), requests})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode the request body: %w", err)
+	}
+
+	u, err := client.endpoint(bulkPath)
+	if err != nil {
+		return nil, err
+	}
+
+	response, _, err := client.execute(ctx, &apiRequest{method: http.MethodPost, url: u, body: body})
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	data, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the bulk response: %w", err)
+	}
+
+	items, err := decodeBulkItems(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) != options.Len() {
+		return nil, fmt.Errorf("the server returned %d items for %d requests", len(items), options.Len())
+	}
+
+	return items, nil
+}
+
+// decodeBulkItems decodes the bulk response, either an object with the "responses" field or an array of items.
+func decodeBulkItems(data []byte) ([]*BulkItem, error) {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		var items []*BulkItem
+		if err := json.Unmarshal(data, &items); err != nil {
+			return nil, fmt.Errorf("failed to decode the bulk response: %w", err)
+		}
+
+		return items, nil
+	}
+
+	var wrapper struct {
+		Responses []*BulkItem `json:"responses"`
+	}
+	if err := json.Unmarshal(data, &wrapper); err != nil {
+		return nil, fmt.Errorf("failed to decode the bulk response: %w", err)
+	}
+
+	return wrapper.Responses, nil
+}
+
+// BulkResult is the result of a single request taken by TakeConcurrently.
+type BulkResult struct {
+	// Index is the index of the request in the bulk.
+	Index int
+	// Result is the screenshot, nil if the request failed.
+	Result *TakeResult
+	// Err is the error of the request.
+	Err error
+}
+
+// TakeConcurrently takes screenshots for all the requests of the bulk with at most concurrency requests in flight
+// and streams the results as they finish. It is a client-side fan-out of Take, one request per screenshot,
+// and does not use the bulk method, see Bulk with Execute for rendering in a single request.
+// The channel is closed when all the requests are done or the context is done,
+// in which case the remaining requests fail with the context error.
+// The caller must read all the results from the channel.
+func (client *Client) TakeConcurrently(ctx context.Context, options *BulkOptions, concurrency int) <-chan *BulkResult {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	requests := make([]*TakeOptions, 0, options.Len())
+	for index := 0; index < options.Len(); index++ {
+		requests = append(requests, options.Options(index))
+	}
+
+	batch := &Batch{client: client, concurrency: concurrency}
+
+	results := make(chan *BulkResult)
+	go func() {
+		defer close(results)
+
+		for result := range batch.Run(ctx, requests) {
+			results <- &BulkResult{Index: result.Index, Result: result.Result, Err: result.Err}
+		}
+	}()
+
+	return results
+}
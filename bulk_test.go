@@ -0,0 +1,126 @@
+package gosdk_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"testing"
+
+	screenshots "github.com/screenshotone/gosdk"
+)
+
+func TestBulkSendsRequests(t *testing.T) {
+	roundTripper := &mockRoundTripper{
+		statusCode: http.StatusOK,
+		body:       []byte(`{"responses":[{"url":"https://api.screenshotone.com/take?url=https%3A%2F%2Fexample.com"},{"url":"https://api.screenshotone.com/take?url=https%3A%2F%2Fexample.org","response":{"is_successful":true,"status":200,"statusText":"OK"}}]}`),
+	}
+
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: roundTripper},
+		screenshots.WithDefaultOptions(screenshots.NewTakeDefaults().BlockAds(true)),
+	)
+	ok(t, err)
+
+	shared := screenshots.NewTakeDefaults().Format("png")
+	request := screenshots.NewTakeOptions("https://example.org").Format("jpg")
+	options := screenshots.NewBulkOptions(shared).
+		Add(screenshots.NewTakeOptions("https://example.com")).
+		Add(request).
+		Execute(true)
+
+	// the bulk keeps copies of the options
+	shared.Format("webp")
+	request.Format("webp")
+
+	items, err := client.Bulk(context.Background(), options)
+	ok(t, err)
+
+	equals(t, http.MethodPost, roundTripper.request.Method)
+	equals(t, "/bulk", roundTripper.request.URL.Path)
+
+	data, err := ioutil.ReadAll(roundTripper.request.Body)
+	ok(t, err)
+
+	var body map[string]interface{}
+	ok(t, json.Unmarshal(data, &body))
+	equals(t, map[string]interface{}{
+		"access_key": "test-key",
+		"execute":    true,
+		"options":    map[string]interface{}{"block_ads": true, "format": "png"},
+		"requests": []interface{}{
+			map[string]interface{}{"url": "https://example.com"},
+			map[string]interface{}{"url": "https://example.org", "format": "jpg"},
+		},
+	}, body)
+
+	equals(t, 2, len(items))
+	equals(t, "https://api.screenshotone.com/take?url=https%3A%2F%2Fexample.com", items[0].URL)
+	equals(t, (*screenshots.BulkItemResponse)(nil), items[0].Response)
+	equals(t, &screenshots.BulkItemResponse{IsSuccessful: true, Status: 200, StatusText: "OK"}, items[1].Response)
+}
+
+func TestBulkRejectsMismatchedResponses(t *testing.T) {
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: &mockRoundTripper{
+		statusCode: http.StatusOK,
+		body:       []byte(`[]`),
+	}})
+	ok(t, err)
+
+	_, err = client.Bulk(context.Background(), screenshots.NewBulkOptions(nil).Add(screenshots.NewTakeOptions("https://example.com")))
+	errorred(t, err, "the server returned 0 items for 1 requests")
+
+	_, err = client.Bulk(context.Background(), screenshots.NewBulkOptions(nil))
+	errorred(t, err, "at least one request is required")
+}
+
+func TestBulkValidatesRequests(t *testing.T) {
+	roundTripper := &mockRoundTripper{statusCode: http.StatusOK, body: []byte(`[]`)}
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: roundTripper},
+		screenshots.WithValidation(),
+	)
+	ok(t, err)
+
+	options := screenshots.NewBulkOptions(screenshots.NewTakeDefaults().Format("png")).
+		Add(screenshots.NewTakeOptions("https://example.com")).
+		Add(screenshots.NewTakeOptions("https://example.org").ImageQuality(80))
+
+	_, err = client.Bulk(context.Background(), options)
+	errorred(t, err, "request 1: invalid options: image_quality: is only available for the")
+	equals(t, (*http.Request)(nil), roundTripper.request)
+}
+
+func TestTakeConcurrentlyStreamsResults(t *testing.T) {
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: &urlEchoRoundTripper{}})
+	ok(t, err)
+
+	options := screenshots.NewBulkOptions(screenshots.NewTakeDefaults().Format("png"))
+	for _, u := range []string{"https://example.com", "https://example.org", "https://example.net"} {
+		options.Add(screenshots.NewTakeOptions(u))
+	}
+
+	var results []*screenshots.BulkResult
+	for result := range client.TakeConcurrently(context.Background(), options, 2) {
+		results = append(results, result)
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Index < results[j].Index })
+
+	equals(t, 3, len(results))
+	for i, u := range []string{"https://example.com", "https://example.org", "https://example.net"} {
+		ok(t, results[i].Err)
+		equals(t, i, results[i].Index)
+		equals(t, u+" png", string(results[i].Result.Body))
+	}
+}
+
+// urlEchoRoundTripper responds with the url and format options of the request, it is safe for concurrent use.
+type urlEchoRoundTripper struct{}
+
+func (m *urlEchoRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	query := req.URL.Query()
+
+	return (&mockRoundTripper{
+		statusCode: http.StatusOK,
+		body:       []byte(query.Get("url") + " " + query.Get("format")),
+	}).RoundTrip(req)
+}
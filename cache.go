@@ -0,0 +1,372 @@
+package gosdk
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrCacheMiss is returned by caches when the entry is not found or expired.
+var ErrCacheMiss = errors.New("cache miss")
+
+// CacheEntry is a cached response of the take method.
+type CacheEntry struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// Cache stores responses of the take method locally, so identical requests are served without the API.
+// Keys are hashes of the canonical signed query. Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the entry by the key or ErrCacheMiss.
+	Get(ctx context.Context, key string) (*CacheEntry, error)
+	// Put stores the entry by the key.
+	Put(ctx context.Context, key string, entry *CacheEntry) error
+	// Delete removes the entry by the key, it is not an error if the entry does not exist.
+	Delete(ctx context.Context, key string) error
+}
+
+// WithCache makes Take serve identical requests from the cache and store successful responses in it.
+// The cache is best effort: errors of the cache are treated as misses.
+// Requests with side effects, i.e. storing the screenshot, async rendering or webhooks,
+// and requests for JSON responses, which contain expiring URLs, always reach the API.
+func WithCache(cache Cache) ClientOption {
+	return func(client *Client) error {
+		if cache == nil {
+			return fmt.Errorf("cache is required")
+		}
+
+		client.cache = cache
+
+		return nil
+	}
+}
+
+type bypassCacheKey struct{}
+
+// BypassCache returns the context making Take skip the cache lookup.
+// The fresh response still replaces the cached one.
+func BypassCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassCacheKey{}, true)
+}
+
+func cacheBypassed(ctx context.Context) bool {
+	bypass, _ := ctx.Value(bypassCacheKey{}).(bool)
+
+	return bypass
+}
+
+// cacheable reports whether the response to the options can be served from the cache.
+func (client *Client) cacheable(options *TakeOptions) bool {
+	query := client.query(options)
+	for _, option := range []string{"store", "async"} {
+		if enabled, _ := strconv.ParseBool(query.Get(option)); enabled {
+			return false
+		}
+	}
+
+	return query.Get("webhook_url") == "" && query.Get("response_type") != string(ResponseTypeJSON)
+}
+
+// cacheKey returns the hash of the canonical signed query of the options.
+func (client *Client) cacheKey(options *TakeOptions) (string, error) {
+	query, err := client.validatedQuery(options)
+	if err != nil {
+		return "", err
+	}
+
+	queryString := canonicalQuery(query).Encode()
+	if client.secretKey != "" {
+		queryString += "&signature=" + sign(client.secretKey, queryString)
+	}
+	sum := sha256.Sum256([]byte(queryString))
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// takeCached takes screenshot through the cache of the client.
+func (client *Client) takeCached(ctx context.Context, options *TakeOptions) (*TakeResult, error) {
+	key, err := client.cacheKey(options)
+	if err != nil {
+		return nil, err
+	}
+
+	if !cacheBypassed(ctx) {
+		if entry, err := client.cache.Get(ctx, key); err == nil {
+			// copy the entry, so changes of the result do not affect the cache
+			response := &http.Response{StatusCode: entry.StatusCode, Header: entry.Header.Clone()}
+			result := newTakeResult(response, append([]byte(nil), entry.Body...), 0)
+			result.Cached = true
+
+			return result, nil
+		}
+	}
+
+	result, err := client.takeUncached(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = client.cache.Put(ctx, key, &CacheEntry{
+		StatusCode: result.StatusCode,
+		Header:     result.Header.Clone(),
+		Body:       append([]byte(nil), result.Body...),
+	})
+
+	return result, nil
+}
+
+// MemoryCache is an in-memory Cache evicting the least recently used entries.
+type MemoryCache struct {
+	mu       sync.Mutex
+	maxBytes int64
+	ttl      time.Duration
+	size     int64
+	items    map[string]*list.Element
+	order    *list.List
+}
+
+type memoryCacheItem struct {
+	key     string
+	entry   *CacheEntry
+	size    int64
+	expires time.Time
+}
+
+// NewMemoryCache returns an in-memory cache holding at most maxBytes of response bodies
+// with entries expiring after the ttl. Zero or a negative value disables the limit.
+func NewMemoryCache(maxBytes int64, ttl time.Duration) *MemoryCache {
+	return &MemoryCache{
+		maxBytes: maxBytes,
+		ttl:      ttl,
+		items:    make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// Get returns the entry by the key or ErrCacheMiss.
+func (c *MemoryCache) Get(ctx context.Context, key string) (*CacheEntry, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.items[key]
+	if !ok {
+		return nil, ErrCacheMiss
+	}
+
+	item := element.Value.(*memoryCacheItem)
+	if !item.expires.IsZero() && time.Now().After(item.expires) {
+		c.remove(element)
+
+		return nil, ErrCacheMiss
+	}
+	c.order.MoveToFront(element)
+
+	return item.entry, nil
+}
+
+// Put stores the entry by the key. Entries larger than the cache are not stored.
+func (c *MemoryCache) Put(ctx context.Context, key string, entry *CacheEntry) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.items[key]; ok {
+		c.remove(element)
+	}
+
+	item := &memoryCacheItem{key: key, entry: entry, size: int64(len(entry.Body))}
+	if c.maxBytes > 0 && item.size > c.maxBytes {
+		return nil
+	}
+	if c.ttl > 0 {
+		item.expires = time.Now().Add(c.ttl)
+	}
+
+	c.items[key] = c.order.PushFront(item)
+	c.size += item.size
+
+	for c.maxBytes > 0 && c.size > c.maxBytes {
+		c.remove(c.order.Back())
+	}
+
+	return nil
+}
+
+// Delete removes the entry by the key.
+func (c *MemoryCache) Delete(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.items[key]; ok {
+		c.remove(element)
+	}
+
+	return nil
+}
+
+// Len returns the number of entries in the cache, including the expired ones not yet removed.
+func (c *MemoryCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+func (c *MemoryCache) remove(element *list.Element) {
+	item := c.order.Remove(element).(*memoryCacheItem)
+	delete(c.items, item.key)
+	c.size -= item.size
+}
+
+// diskCacheSuffix is the file name suffix of the disk cache entries.
+const diskCacheSuffix = ".cache"
+
+// DiskCache is a Cache storing entries as files in a directory, evicting the oldest entries.
+// It can be shared by many processes.
+type DiskCache struct {
+	mu       sync.Mutex
+	dir      string
+	maxBytes int64
+	ttl      time.Duration
+}
+
+// NewDiskCache returns a cache storing at most maxBytes of files in the directory
+// with entries expiring after the ttl. Zero or a negative value disables the limit.
+// The directory is created if it does not exist.
+func NewDiskCache(dir string, maxBytes int64, ttl time.Duration) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create the cache directory: %w", err)
+	}
+
+	return &DiskCache{dir: dir, maxBytes: maxBytes, ttl: ttl}, nil
+}
+
+// Get returns the entry by the key or ErrCacheMiss.
+func (c *DiskCache) Get(ctx context.Context, key string) (*CacheEntry, error) {
+	path := c.path(key)
+
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return nil, ErrCacheMiss
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the cache entry: %w", err)
+	}
+	if c.ttl > 0 && time.Since(info.ModTime()) > c.ttl {
+		_ = os.Remove(path)
+
+		return nil, ErrCacheMiss
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, ErrCacheMiss
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the cache entry: %w", err)
+	}
+
+	entry := &CacheEntry{}
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(entry); err != nil {
+		return nil, fmt.Errorf("failed to decode the cache entry: %w", err)
+	}
+
+	return entry, nil
+}
+
+// Put stores the entry by the key and evicts the oldest entries if the cache is too large.
+func (c *DiskCache) Put(ctx context.Context, key string, entry *CacheEntry) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(entry); err != nil {
+		return fmt.Errorf("failed to encode the cache entry: %w", err)
+	}
+
+	// write to a temporary file first, so readers never see partially written entries
+	file, err := ioutil.TempFile(c.dir, "tmp-")
+	if err != nil {
+		return fmt.Errorf("failed to write the cache entry: %w", err)
+	}
+	_, err = file.Write(buf.Bytes())
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), c.path(key))
+	}
+	if err != nil {
+		_ = os.Remove(file.Name())
+
+		return fmt.Errorf("failed to write the cache entry: %w", err)
+	}
+
+	return c.evict()
+}
+
+// Delete removes the entry by the key.
+func (c *DiskCache) Delete(ctx context.Context, key string) error {
+	if err := os.Remove(c.path(key)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete the cache entry: %w", err)
+	}
+
+	return nil
+}
+
+// path returns the file path of the entry, keys are hashed to be safe file names.
+func (c *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+diskCacheSuffix)
+}
+
+// evict removes the oldest entries until the cache fits into maxBytes.
+func (c *DiskCache) evict() error {
+	if c.maxBytes <= 0 {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	infos, err := ioutil.ReadDir(c.dir)
+	if err != nil {
+		return fmt.Errorf("failed to list the cache entries: %w", err)
+	}
+
+	var entries []os.FileInfo
+	var size int64
+	for _, info := range infos {
+		if info.IsDir() || !strings.HasSuffix(info.Name(), diskCacheSuffix) {
+			continue
+		}
+		entries = append(entries, info)
+		size += info.Size()
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ModTime().Before(entries[j].ModTime()) })
+
+	for _, info := range entries {
+		if size <= c.maxBytes {
+			break
+		}
+		if err := os.Remove(filepath.Join(c.dir, info.Name())); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to evict the cache entry: %w", err)
+		}
+		size -= info.Size()
+	}
+
+	return nil
+}
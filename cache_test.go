@@ -0,0 +1,201 @@
+package gosdk_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	screenshots "github.com/screenshotone/gosdk"
+)
+
+func TestTakeServesIdenticalRequestsFromCache(t *testing.T) {
+	roundTripper := &countingRoundTripper{}
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: roundTripper},
+		screenshots.WithCache(screenshots.NewMemoryCache(0, 0)))
+	ok(t, err)
+
+	first, err := client.Take(context.Background(), screenshots.NewTakeOptions("https://example.com").Format("png"))
+	ok(t, err)
+	equals(t, false, first.Cached)
+
+	second, err := client.Take(context.Background(), screenshots.NewTakeOptions("https://example.com").Format("png"))
+	ok(t, err)
+	equals(t, true, second.Cached)
+	equals(t, 0, second.Attempts)
+	equals(t, first.Body, second.Body)
+	equals(t, int32(1), roundTripper.calls)
+
+	// options are compared in the canonical form
+	equivalent, err := client.Take(context.Background(), screenshots.NewTakeOptions("https://example.com").Format("png").Delay(0))
+	ok(t, err)
+	equals(t, true, equivalent.Cached)
+
+	_, err = client.Take(context.Background(), screenshots.NewTakeOptions("https://example.com").Format("jpeg"))
+	ok(t, err)
+	equals(t, int32(2), roundTripper.calls)
+
+	bypassed, err := client.Take(screenshots.BypassCache(context.Background()), screenshots.NewTakeOptions("https://example.com").Format("png"))
+	ok(t, err)
+	equals(t, false, bypassed.Cached)
+	equals(t, int32(3), roundTripper.calls)
+}
+
+func TestTakeDoesNotCacheErrors(t *testing.T) {
+	cache := screenshots.NewMemoryCache(0, 0)
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: &mockRoundTripper{
+		statusCode: http.StatusBadRequest,
+		body:       []byte(`{"is_successful":false,"error_code":"selector_not_found"}`),
+	}}, screenshots.WithCache(cache))
+	ok(t, err)
+
+	_, err = client.Take(context.Background(), screenshots.NewTakeOptions("https://example.com"))
+	errorred(t, err, "selector_not_found")
+	equals(t, 0, cache.Len())
+}
+
+func TestTakeSkipsCacheForSideEffectsAndJSON(t *testing.T) {
+	for name, test := range map[string]struct {
+		defaults *screenshots.TakeOptions
+		options  *screenshots.TakeOptions
+	}{
+		"store":             {options: screenshots.NewTakeOptions("https://example.com").Store(true)},
+		"default store":     {defaults: screenshots.NewTakeDefaults().Store(true), options: screenshots.NewTakeOptions("https://example.com")},
+		"async":             {options: screenshots.NewTakeOptions("https://example.com").Async(true)},
+		"webhook URL":       {options: screenshots.NewTakeOptions("https://example.com").WebhookURL("https://example.com/webhook")},
+		"JSON response":     {options: screenshots.NewTakeOptions("https://example.com").ResponseType(screenshots.ResponseTypeJSON)},
+		"default JSON type": {defaults: screenshots.NewTakeDefaults().ResponseType(screenshots.ResponseTypeJSON), options: screenshots.NewTakeOptions("https://example.com")},
+	} {
+		t.Run(name, func(t *testing.T) {
+			roundTripper := &countingRoundTripper{}
+			cache := screenshots.NewMemoryCache(0, 0)
+			opts := []screenshots.ClientOption{screenshots.WithCache(cache)}
+			if test.defaults != nil {
+				opts = append(opts, screenshots.WithDefaultOptions(test.defaults))
+			}
+			client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: roundTripper}, opts...)
+			ok(t, err)
+
+			for i := 0; i < 2; i++ {
+				result, err := client.Take(context.Background(), test.options)
+				ok(t, err)
+				equals(t, false, result.Cached)
+			}
+			equals(t, int32(2), roundTripper.calls)
+			equals(t, 0, cache.Len())
+		})
+	}
+}
+
+func TestMemoryCacheEvictsLeastRecentlyUsedEntries(t *testing.T) {
+	ctx := context.Background()
+	cache := screenshots.NewMemoryCache(10, 0)
+
+	ok(t, cache.Put(ctx, "a", &screenshots.CacheEntry{Body: []byte("aaaa")}))
+	ok(t, cache.Put(ctx, "b", &screenshots.CacheEntry{Body: []byte("bbbb")}))
+	_, err := cache.Get(ctx, "a")
+	ok(t, err)
+	ok(t, cache.Put(ctx, "c", &screenshots.CacheEntry{Body: []byte("cccc")}))
+
+	_, err = cache.Get(ctx, "b")
+	equals(t, screenshots.ErrCacheMiss, err)
+	_, err = cache.Get(ctx, "a")
+	ok(t, err)
+	equals(t, 2, cache.Len())
+
+	ok(t, cache.Put(ctx, "d", &screenshots.CacheEntry{Body: []byte("too large for the cache")}))
+	_, err = cache.Get(ctx, "d")
+	equals(t, screenshots.ErrCacheMiss, err)
+
+	ok(t, cache.Delete(ctx, "a"))
+	_, err = cache.Get(ctx, "a")
+	equals(t, screenshots.ErrCacheMiss, err)
+}
+
+func TestMemoryCacheExpiresEntries(t *testing.T) {
+	ctx := context.Background()
+	cache := screenshots.NewMemoryCache(0, 10*time.Millisecond)
+
+	ok(t, cache.Put(ctx, "a", &screenshots.CacheEntry{Body: []byte("aaaa")}))
+	time.Sleep(20 * time.Millisecond)
+
+	_, err := cache.Get(ctx, "a")
+	equals(t, screenshots.ErrCacheMiss, err)
+}
+
+func TestDiskCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gosdk-cache")
+	ok(t, err)
+	defer os.RemoveAll(dir)
+
+	ctx := context.Background()
+	cache, err := screenshots.NewDiskCache(dir, 0, 0)
+	ok(t, err)
+
+	_, err = cache.Get(ctx, "a")
+	equals(t, screenshots.ErrCacheMiss, err)
+
+	entry := &screenshots.CacheEntry{StatusCode: http.StatusOK, Header: http.Header{"Content-Type": []string{"image/png"}}, Body: []byte("a")}
+	ok(t, cache.Put(ctx, "a", entry))
+
+	cached, err := cache.Get(ctx, "a")
+	ok(t, err)
+	equals(t, entry, cached)
+
+	ok(t, cache.Delete(ctx, "a"))
+	_, err = cache.Get(ctx, "a")
+	equals(t, screenshots.ErrCacheMiss, err)
+	ok(t, cache.Delete(ctx, "a"))
+}
+
+func TestDiskCacheEvictsOldestEntries(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gosdk-cache")
+	ok(t, err)
+	defer os.RemoveAll(dir)
+
+	ctx := context.Background()
+	unbounded, err := screenshots.NewDiskCache(dir, 0, 0)
+	ok(t, err)
+	ok(t, unbounded.Put(ctx, "a", &screenshots.CacheEntry{Body: []byte("a")}))
+	infos, err := ioutil.ReadDir(dir)
+	ok(t, err)
+	entrySize := infos[0].Size()
+
+	// make the first entry older than the next ones
+	old := time.Now().Add(-time.Minute)
+	ok(t, os.Chtimes(filepath.Join(dir, infos[0].Name()), old, old))
+
+	cache, err := screenshots.NewDiskCache(dir, 2*entrySize, 0)
+	ok(t, err)
+	ok(t, cache.Put(ctx, "b", &screenshots.CacheEntry{Body: []byte("b")}))
+	ok(t, cache.Put(ctx, "c", &screenshots.CacheEntry{Body: []byte("c")}))
+
+	_, err = cache.Get(ctx, "a")
+	equals(t, screenshots.ErrCacheMiss, err)
+	_, err = cache.Get(ctx, "b")
+	ok(t, err)
+	_, err = cache.Get(ctx, "c")
+	ok(t, err)
+}
+
+func TestDiskCacheExpiresEntries(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gosdk-cache")
+	ok(t, err)
+	defer os.RemoveAll(dir)
+
+	ctx := context.Background()
+	cache, err := screenshots.NewDiskCache(dir, 0, time.Minute)
+	ok(t, err)
+	ok(t, cache.Put(ctx, "a", &screenshots.CacheEntry{Body: []byte("a")}))
+
+	infos, err := ioutil.ReadDir(dir)
+	ok(t, err)
+	old := time.Now().Add(-time.Hour)
+	ok(t, os.Chtimes(filepath.Join(dir, infos[0].Name()), old, old))
+
+	_, err = cache.Get(ctx, "a")
+	equals(t, screenshots.ErrCacheMiss, err)
+}
@@ -0,0 +1,105 @@
+package gosdk
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strconv"
+)
+
+// defaultOptionValues are the values the API uses for options which are not set.
+var defaultOptionValues = map[string]string{
+	"format":               string(FormatJPG),
+	"response_type":        string(ResponseTypeByFormat),
+	"viewport_width":       "1280",
+	"viewport_height":      "1024",
+	"device_scale_factor":  "1",
+	"delay":                "0",
+	"timeout":              "60",
+	"full_page":            "false",
+	"omit_background":      "false",
+	"block_ads":            "false",
+	"block_trackers":       "false",
+	"block_cookie_banners": "false",
+	"block_chats":          "false",
+	"viewport_mobile":      "false",
+	"viewport_has_touch":   "false",
+	"viewport_landscape":   "false",
+	"cache":                "false",
+	"store":                "false",
+	"async":                "false",
+}
+
+// optionValueAliases maps values the API treats the same way to the canonical value, per option.
+var optionValueAliases = map[string]map[string]string{
+	"format": {string(FormatJPEG): string(FormatJPG)},
+}
+
+// Canonical returns a copy of the options in the canonical form, so options producing the same screenshot
+// are equal regardless of how they were set: booleans and numbers are formatted the same way,
+// values of list options are sorted and deduplicated, aliases like "jpeg" are replaced with "jpg",
+// and options set to the API defaults are removed.
+func (o *TakeOptions) Canonical() *TakeOptions {
+	return &TakeOptions{query: canonicalQuery(o.query)}
+}
+
+// Hash returns the hex-encoded SHA-256 hash of the canonical form of the options.
+// It is stable across processes, so it can be used as a cache key, CacheKey value or database ID,
+// but it may change between releases of the package when its knowledge of the API defaults changes.
+func (o *TakeOptions) Hash() string {
+	sum := sha256.Sum256([]byte(canonicalQuery(o.query).Encode()))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func canonicalQuery(query url.Values) url.Values {
+	canonical := make(url.Values, len(query))
+	for name, values := range query {
+		normalized := make([]string, 0, len(values))
+		for _, value := range values {
+			normalized = append(normalized, canonicalValue(name, value))
+		}
+
+		if listOptions[name] {
+			normalized = sortedUnique(normalized)
+		} else if len(normalized) == 1 && defaultOptionValues[name] == normalized[0] {
+			continue
+		}
+
+		canonical[name] = normalized
+	}
+
+	return canonical
+}
+
+func canonicalValue(name, value string) string {
+	if alias, ok := optionValueAliases[name][value]; ok {
+		value = alias
+	}
+
+	switch v := jsonValue(name, value).(type) {
+	case bool:
+		return strconv.FormatBool(v)
+	case int:
+		return strconv.Itoa(v)
+	case json.Number:
+		return v.String()
+	}
+
+	return value
+}
+
+func sortedUnique(values []string) []string {
+	sort.Strings(values)
+
+	unique := values[:0]
+	for _, value := range values {
+		if len(unique) == 0 || value != unique[len(unique)-1] {
+			unique = append(unique, value)
+		}
+	}
+
+	return unique
+}
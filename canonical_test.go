@@ -0,0 +1,57 @@
+package gosdk_test
+
+import (
+	"testing"
+
+	screenshots "github.com/screenshotone/gosdk"
+)
+
+func TestCanonicalNormalizesOptions(t *testing.T) {
+	options := screenshots.NewTakeOptions("https://example.com").
+		BlockRequests("*.ads.com", "*.analytics.com").
+		Format("png").
+		FullPage(true).
+		ViewportWidth(1920).
+		GeolocationLatitude(48.8).
+		GeolocationLongitude(2.35)
+
+	raw := &screenshots.TakeOptions{}
+	ok(t, raw.UnmarshalText([]byte("block_requests=*.analytics.com&block_requests=*.ads.com&block_requests=*.analytics.com&"+
+		"format=png&full_page=1&delay=0&viewport_width=01920&geolocation_latitude=48.80&geolocation_longitude=2.350&"+
+		"url=https://example.com")))
+
+	equals(t, options.Hash(), raw.Hash())
+
+	canonical := raw.Canonical()
+	equals(t, []string{"*.ads.com", "*.analytics.com"}, canonical.Values("block_requests"))
+	equals(t, "true", canonical.Get("full_page"))
+	equals(t, "1920", canonical.Get("viewport_width"))
+	equals(t, "48.8", canonical.Get("geolocation_latitude"))
+	equals(t, false, canonical.Has("delay"))
+	equals(t, "01920", raw.Get("viewport_width"))
+}
+
+func TestCanonicalRemovesDefaults(t *testing.T) {
+	options := screenshots.NewTakeOptions("https://example.com").
+		Format("jpg").
+		ViewportWidth(1280).
+		ViewportHeight(1024).
+		BlockAds(false).
+		ResponseType(screenshots.ResponseTypeByFormat)
+
+	equals(t, screenshots.NewTakeOptions("https://example.com").Hash(), options.Hash())
+	equals(t, false, options.Canonical().Has("format"))
+
+	// aliases of the default are removed too
+	equals(t, options.Hash(), screenshots.NewTakeOptions("https://example.com").Format("jpeg").Hash())
+	equals(t, false, screenshots.NewTakeOptions("https://example.com").Format("jpeg").Canonical().Has("format"))
+	equals(t, true, options.Has("format"))
+}
+
+func TestHashDistinguishesOptions(t *testing.T) {
+	equals(t, 64, len(screenshots.NewTakeOptions("https://example.com").Hash()))
+
+	if screenshots.NewTakeOptions("https://example.com").FullPage(true).Hash() == screenshots.NewTakeOptions("https://example.com").Hash() {
+		t.Fatal("expected different hashes for different options")
+	}
+}
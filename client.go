@@ -1,121 +1,410 @@
 package gosdk
 
 import (
+	"bytes"
 	"context"
-	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/hex"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strconv"
 )
 
-const baseURL = "https://api.screenshotone.com"
+const defaultBaseURL = "https://api.screenshotone.com"
 const takePath = "/take"
 
 // Client API client for the ScreenshotOne.com API.
 type Client struct {
 	accessKey, secretKey string
 
-	httpClient *http.Client
+	httpClient     *http.Client
+	baseURL        string
+	userAgent      string
+	defaultOptions *TakeOptions
+	retryPolicy    *RetryPolicy
+	rateLimiter    *RateLimiter
+	cache          Cache
+	postThreshold  int
+	alwaysPOST     bool
+	validate       bool
+
+	asyncDispatcher *AsyncDispatcher
 }
 
 // NewClient returns new API client for the ScreenshotOne.com API.
-func NewClient(accessKey, secretKey string) (*Client, error) {
-	client := &Client{accessKey, secretKey, &http.Client{}}
+func NewClient(accessKey, secretKey string, opts ...ClientOption) (*Client, error) {
+	client := &Client{
+		accessKey:     accessKey,
+		secretKey:     secretKey,
+		httpClient:    &http.Client{},
+		baseURL:       defaultBaseURL,
+		postThreshold: defaultPOSTThreshold,
+	}
+
+	for _, opt := range opts {
+		if err := opt(client); err != nil {
+			return nil, err
+		}
+	}
 
 	return client, nil
 }
 
 // NewClientWithHTTPClient returns new API client for the ScreenshotOne.com API with a custom HTTP client.
-func NewClientWithHTTPClient(accessKey, secretKey string, httpClient *http.Client) (*Client, error) {
-	client := &Client{accessKey, secretKey, httpClient}
-
-	return client, nil
+func NewClientWithHTTPClient(accessKey, secretKey string, httpClient *http.Client, opts ...ClientOption) (*Client, error) {
+	return NewClient(accessKey, secretKey, append([]ClientOption{WithHTTPClient(httpClient)}, opts...)...)
 }
 
 // GenerateTakeURL generates URL for taking screenshots with request signing.
 func (client *Client) GenerateTakeURL(options *TakeOptions) (*url.URL, error) {
+	query, err := client.validatedQuery(options)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.signedURL(takePath, query)
+}
+
+// GenerateUnsignedTakeURL generates URL for taking screenshots without signing the request.
+func (client *Client) GenerateUnsignedTakeURL(options *TakeOptions) (*url.URL, error) {
+	// generate query
+	query, err := client.validatedQuery(options)
+	if err != nil {
+		return nil, err
+	}
+	queryString := query.Encode()
+
+	u, err := client.endpoint(takePath)
+	if err != nil {
+		return nil, err
+	}
+	u.RawQuery = queryString
+
+	return u, nil
+}
+
+// signedURL generates URL of the endpoint with the path for the query with the signature appended.
+func (client *Client) signedURL(path string, query url.Values) (*url.URL, error) {
 	if client.secretKey == "" {
 		return nil, fmt.Errorf("secret key is required for signed URLs")
 	}
 
-	// generate query
-	query := options.query
-	query.Set("access_key", client.accessKey)
 	queryString := query.Encode()
 
 	// sign the query string and append the signature
-	hash := hmac.New(sha256.New, []byte(client.secretKey))
-	_, err := hash.Write([]byte(queryString))
+	queryString += "&signature=" + sign(client.secretKey, queryString)
+
+	u, err := client.endpoint(path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to sign the query string: %w", err)
+		return nil, err
+	}
+	u.RawQuery = queryString
+
+	return u, nil
+}
+
+// validatedQuery returns the request query and validates it if the client is configured to.
+func (client *Client) validatedQuery(options *TakeOptions) (url.Values, error) {
+	query := client.query(options)
+	if client.validate {
+		if err := validateQuery(query); err != nil {
+			return nil, err
+		}
 	}
-	signature := hex.EncodeToString(hash.Sum(nil))
-	queryString += "&signature=" + signature
 
-	u, err := url.Parse(baseURL + takePath)
+	return query, nil
+}
+
+// endpoint returns the URL of the API endpoint with the path.
+func (client *Client) endpoint(path string) (*url.URL, error) {
+	u, err := url.Parse(client.baseURL + path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse URL \"%s\": %w", baseURL+takePath, err)
+		return nil, fmt.Errorf("failed to parse URL \"%s\": %w", client.baseURL+path, err)
 	}
-	u.RawQuery = queryString
 
 	return u, nil
 }
 
-// GenerateUnsignedTakeURL generates URL for taking screenshots without signing the request.
-func (client *Client) GenerateUnsignedTakeURL(options *TakeOptions) (*url.URL, error) {
-	// generate query
-	query := options.query
+// query returns a copy of the request query with the default options and the access key applied.
+// The options are never modified.
+func (client *Client) query(options *TakeOptions) url.Values {
+	var defaults url.Values
+	if client.defaultOptions != nil {
+		defaults = client.defaultOptions.query
+	}
+	query := mergeQuery(defaults, options.query)
 	query.Set("access_key", client.accessKey)
-	queryString := query.Encode()
 
-	u, err := url.Parse(baseURL + takePath)
+	return query
+}
+
+// mergeQuery returns a copy of the query with the default options applied,
+// the options set in the query take precedence over the defaults.
+func mergeQuery(defaults, query url.Values) url.Values {
+	merged := url.Values{}
+	for key, values := range defaults {
+		if sourceOptions[key] {
+			continue
+		}
+		if _, ok := query[key]; !ok {
+			merged[key] = append([]string(nil), values...)
+		}
+	}
+	for key, values := range query {
+		merged[key] = append([]string(nil), values...)
+	}
+
+	return merged
+}
+
+// Take takes screenshot and returns the result or error if the request failed.
+func (client *Client) Take(ctx context.Context, options *TakeOptions) (*TakeResult, error) {
+	if client.cache != nil && client.cacheable(options) {
+		return client.takeCached(ctx, options)
+	}
+
+	return client.takeUncached(ctx, options)
+}
+
+func (client *Client) takeUncached(ctx context.Context, options *TakeOptions) (*TakeResult, error) {
+	response, attempts, err := client.take(ctx, options)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse URL \"%s\": %w", baseURL+takePath, err)
+		return nil, err
 	}
-	u.RawQuery = queryString
+	defer response.Body.Close()
 
-	return u, nil
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the image data from HTTP response: %w", err)
+	}
+
+	return newTakeResult(response, body, attempts), nil
+}
+
+// TakeTo takes screenshot and writes it to w without buffering the whole image in memory.
+// The Body of the returned result is nil.
+func (client *Client) TakeTo(ctx context.Context, w io.Writer, options *TakeOptions) (*TakeResult, error) {
+	response, attempts, err := client.take(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	_, err = io.Copy(w, response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write the image data from HTTP response: %w", err)
+	}
+
+	return newTakeResult(response, nil, attempts), nil
+}
+
+// TakeStream takes screenshot and returns the response body as a stream along with the response headers.
+// The caller must close the returned stream.
+func (client *Client) TakeStream(ctx context.Context, options *TakeOptions) (io.ReadCloser, http.Header, error) {
+	response, _, err := client.take(ctx, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return response.Body, response.Header, nil
 }
 
-// Take takes screenshot and returns image or error if the request failed.
-func (client *Client) Take(ctx context.Context, options *TakeOptions) ([]byte, *http.Response, error) {
-	u, err := client.GenerateTakeURL(options)
+// take executes the take request and returns the successful response with the unread body
+// along with the number of attempts made.
+func (client *Client) take(ctx context.Context, options *TakeOptions) (*http.Response, int, error) {
+	request, err := client.newTakeRequest(options)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to generate URL: %w", err)
+		return nil, 0, err
+	}
+
+	return client.execute(ctx, request)
+}
+
+// execute executes the request retrying it according to the retry policy and returns the successful response
+// with the unread body along with the number of attempts made.
+func (client *Client) execute(ctx context.Context, request *apiRequest) (*http.Response, int, error) {
+	maxAttempts := 1
+	if client.retryPolicy != nil && client.retryPolicy.MaxAttempts > 1 {
+		maxAttempts = client.retryPolicy.MaxAttempts
+	}
+
+	for attempt := 1; ; attempt++ {
+		release, err := client.rateLimiter.wait(ctx)
+		if err != nil {
+			return nil, attempt, fmt.Errorf("failed to wait for the rate limiter: %w", err)
+		}
+
+		response, err := client.do(ctx, request)
+		if err == nil {
+			response.Body = &releaseOnClose{ReadCloser: response.Body, release: release}
+
+			return response, attempt, nil
+		}
+		release()
+		client.rateLimiter.observe(err)
+
+		if attempt >= maxAttempts || !client.retryPolicy.retryable(err) {
+			return nil, attempt, err
+		}
+
+		if err := sleep(ctx, client.retryPolicy.delay(attempt, err)); err != nil {
+			return nil, attempt, fmt.Errorf("failed to wait before retrying the request: %w", err)
+		}
 	}
+}
+
+// apiRequest is a prepared API request which can be executed multiple times.
+type apiRequest struct {
+	method string
+	url    *url.URL
+	body   []byte
+}
 
-	request, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+// newTakeRequest prepares a GET request with the signed URL or,
+// for large options or when configured, a POST request with the JSON body.
+// POST requests are authenticated by the access key in the body and are not signed,
+// but the secret key is required for both, so the request method never decides whether a request fails.
+func (client *Client) newTakeRequest(options *TakeOptions) (*apiRequest, error) {
+	if client.secretKey == "" {
+		return nil, fmt.Errorf("secret key is required for signed URLs")
+	}
+
+	query, err := client.validatedQuery(options)
+	if err != nil {
+		return nil, err
+	}
+
+	if !client.alwaysPOST && (client.postThreshold <= 0 || len(query.Encode()) <= client.postThreshold) {
+		u, err := client.signedURL(takePath, query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate URL: %w", err)
+		}
+
+		return &apiRequest{method: http.MethodGet, url: u}, nil
+	}
+
+	body, err := encodeJSONBody(query)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to instantiate HTTP request: %w", err)
+		return nil, fmt.Errorf("failed to encode the request body: %w", err)
+	}
+
+	u, err := client.endpoint(takePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiRequest{method: http.MethodPost, url: u, body: body}, nil
+}
+
+// do executes a single request and returns the successful response with the unread body.
+func (client *Client) do(ctx context.Context, prepared *apiRequest) (*http.Response, error) {
+	var body io.Reader
+	if prepared.body != nil {
+		body = bytes.NewReader(prepared.body)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, prepared.method, prepared.url.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to instantiate HTTP request: %w", err)
+	}
+	if prepared.body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	if client.userAgent != "" {
+		request.Header.Set("User-Agent", client.userAgent)
 	}
 
 	response, err := client.httpClient.Do(request)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to execute HTTP request: %w", err)
+		return nil, &transportError{err}
 	}
 
 	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusCreated {
-		return nil, response, fmt.Errorf("the server returned a response: %d %s", response.StatusCode, response.Status)
+		defer response.Body.Close()
+
+		return nil, newAPIError(response)
 	}
 
-	defer response.Body.Close()
-	image, err := ioutil.ReadAll(response.Body)
+	return response, nil
+}
+
+// TakeBytes takes screenshot and returns image or error if the request failed.
+//
+// Deprecated: use Take, which returns the response metadata along with the image.
+func (client *Client) TakeBytes(ctx context.Context, options *TakeOptions) ([]byte, *http.Response, error) {
+	result, err := client.Take(ctx, options)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to read the image data from HTTP response: %w", err)
+		return nil, nil, err
 	}
 
-	return image, nil, nil
+	return result.Body, nil, nil
 }
 
 // TakeOptions for the ScreenshotOne.com API take method.
+//
+// Setters of scalar options replace the previously set value, while setters of list options,
+// e.g. Cookies, Headers, BlockRequests or HideSelectors, append to the previously set values.
+//
+// The client never modifies options, so the same options can be used by multiple clients
+// and goroutines concurrently as long as none of them calls the setters.
+// To customize shared options per request, call setters on a copy returned by Clone.
 type TakeOptions struct {
 	query url.Values
 }
 
+// Clone returns a deep copy of the options which can be modified independently.
+func (o *TakeOptions) Clone() *TakeOptions {
+	query := make(url.Values, len(o.query))
+	for key, values := range o.query {
+		query[key] = append([]string(nil), values...)
+	}
+
+	return &TakeOptions{query: query}
+}
+
+// listOptions are the options accepting multiple values.
+var listOptions = map[string]bool{
+	"block_requests":  true,
+	"block_resources": true,
+	"cookies":         true,
+	"headers":         true,
+	"wait_until":      true,
+	"hide_selectors":  true,
+}
+
+// Has reports whether the option is set, name is the API name of the option, e.g. "full_page".
+func (o *TakeOptions) Has(name string) bool {
+	_, ok := o.query[name]
+
+	return ok
+}
+
+// Get returns the first value of the option or an empty string if the option is not set.
+func (o *TakeOptions) Get(name string) string {
+	return o.query.Get(name)
+}
+
+// Values returns all the values of the option, e.g. all the cookies.
+func (o *TakeOptions) Values(name string) []string {
+	return append([]string(nil), o.query[name]...)
+}
+
+// Unset removes the option with all its values, so a template can be overridden per request.
+func (o *TakeOptions) Unset(name string) *TakeOptions {
+	o.query.Del(name)
+
+	return o
+}
+
+// sourceOptions are the options specifying what to render, they are never taken from the default options.
+var sourceOptions = map[string]bool{"url": true, "html": true, "markdown": true}
+
+// NewTakeDefaults returns options without a page to render for use with WithDefaultOptions.
+func NewTakeDefaults() *TakeOptions {
+	return &TakeOptions{query: url.Values{}}
+}
+
 // Returns options for the ScreenshotOne.com API take method.
 func NewTakeOptions(pageURL string) *TakeOptions {
 	query := url.Values{}
@@ -150,77 +439,77 @@ func NewTakeWithMarkdown(markdown string) *TakeOptions {
 
 // Selector is a CSS-like selector of the element to take a screenshot of.
 func (o *TakeOptions) Selector(selector string) *TakeOptions {
-	o.query.Add("selector", selector)
+	o.query.Set("selector", selector)
 
 	return o
 }
 
-// SelectorAlgorithm sets the algorithm for finding selectors.
-func (o *TakeOptions) SelectorAlgorithm(algorithm string) *TakeOptions {
-	o.query.Add("selector_algorithm", algorithm)
+// SelectorAlgorithm sets the algorithm for finding selectors, e.g. SelectorAlgorithmCSS.
+func (o *TakeOptions) SelectorAlgorithm(algorithm SelectorAlgorithm) *TakeOptions {
+	o.query.Set("selector_algorithm", string(algorithm))
 
 	return o
 }
 
 // ErrorOnSelectorNotFound determines the behavior of what to do when selector is not found.
 func (o *TakeOptions) ErrorOnSelectorNotFound(errorOn bool) *TakeOptions {
-	o.query.Add("error_on_selector_not_found", strconv.FormatBool(errorOn))
+	o.query.Set("error_on_selector_not_found", strconv.FormatBool(errorOn))
 
 	return o
 }
 
 // FullPage renders the full page.
 func (o *TakeOptions) FullPage(fullPage bool) *TakeOptions {
-	o.query.Add("full_page", strconv.FormatBool(fullPage))
+	o.query.Set("full_page", strconv.FormatBool(fullPage))
 
 	return o
 }
 
-// Format sets response format, one of: "png", "jpeg", "webp" or "jpg".
-func (o *TakeOptions) Format(format string) *TakeOptions {
-	o.query.Add("format", format)
+// Format sets response format, e.g. FormatPNG, FormatJPEG, FormatWebP or FormatPDF.
+func (o *TakeOptions) Format(format Format) *TakeOptions {
+	o.query.Set("format", string(format))
 
 	return o
 }
 
 // Styles specifies custom CSS styles for the page.
 func (o *TakeOptions) Styles(styles string) *TakeOptions {
-	o.query.Add("styles", styles)
+	o.query.Set("styles", styles)
 
 	return o
 }
 
 // Scripts specifies custom scripts for the page.
 func (o *TakeOptions) Scripts(scripts string) *TakeOptions {
-	o.query.Add("scripts", scripts)
+	o.query.Set("scripts", scripts)
 
 	return o
 }
 
 // ScriptsWaitUntil sets when to wait for scripts to complete.
-func (o *TakeOptions) ScriptsWaitUntil(waitUntil string) *TakeOptions {
-	o.query.Add("scripts_wait_until", waitUntil)
+func (o *TakeOptions) ScriptsWaitUntil(waitUntil WaitUntilEvent) *TakeOptions {
+	o.query.Set("scripts_wait_until", string(waitUntil))
 
 	return o
 }
 
 // ImageQuality renders image with the specified quality. Available for the next formats: "jpeg" ("jpg"), "webp".
 func (o *TakeOptions) ImageQuality(imageQuality int) *TakeOptions {
-	o.query.Add("image_quality", strconv.Itoa(imageQuality))
+	o.query.Set("image_quality", strconv.Itoa(imageQuality))
 
 	return o
 }
 
 // ImageHeight sets the height of the resulting image (pixels).
 func (o *TakeOptions) ImageHeight(imageHeight int) *TakeOptions {
-	o.query.Add("image_height", strconv.Itoa(imageHeight))
+	o.query.Set("image_height", strconv.Itoa(imageHeight))
 
 	return o
 }
 
 // ImageWidth sets the width of the resulting image (pixels).
 func (o *TakeOptions) ImageWidth(imageWidth int) *TakeOptions {
-	o.query.Add("image_width", strconv.Itoa(imageWidth))
+	o.query.Set("image_width", strconv.Itoa(imageWidth))
 
 	return o
 }
@@ -228,28 +517,28 @@ func (o *TakeOptions) ImageWidth(imageWidth int) *TakeOptions {
 // OmitBackground renders a transparent background for the image. Works only if the site has not defined background color.
 // Available for the following response formats: "png", "webp".
 func (o *TakeOptions) OmitBackground(omitBackground bool) *TakeOptions {
-	o.query.Add("omit_background", strconv.FormatBool(omitBackground))
+	o.query.Set("omit_background", strconv.FormatBool(omitBackground))
 
 	return o
 }
 
 // ViewportWidth sets the width of the browser viewport (pixels).
 func (o *TakeOptions) ViewportWidth(viewportWidth int) *TakeOptions {
-	o.query.Add("viewport_width", strconv.Itoa(viewportWidth))
+	o.query.Set("viewport_width", strconv.Itoa(viewportWidth))
 
 	return o
 }
 
 // ViewportWidth sets the height of the browser viewport (pixels).
 func (o *TakeOptions) ViewportHeight(viewportHeight int) *TakeOptions {
-	o.query.Add("viewport_height", strconv.Itoa(viewportHeight))
+	o.query.Set("viewport_height", strconv.Itoa(viewportHeight))
 
 	return o
 }
 
 // DeviceScaleFactor sets the device scale factor. Acceptable value is one of: 1, 2 or 3.
 func (o *TakeOptions) DeviceScaleFactor(deviceScaleFactor int) *TakeOptions {
-	o.query.Add("device_scale_factor", strconv.Itoa(deviceScaleFactor))
+	o.query.Set("device_scale_factor", strconv.Itoa(deviceScaleFactor))
 
 	return o
 }
@@ -257,7 +546,7 @@ func (o *TakeOptions) DeviceScaleFactor(deviceScaleFactor int) *TakeOptions {
 // GeolocationLatitude sets geolocation latitude for the request.
 // Both latitude and longitude are required if one of them is set.
 func (o *TakeOptions) GeolocationLatitude(latitude float64) *TakeOptions {
-	o.query.Add("geolocation_latitude", strconv.FormatFloat(latitude, byte('f'), -1, 64))
+	o.query.Set("geolocation_latitude", strconv.FormatFloat(latitude, byte('f'), -1, 64))
 
 	return o
 }
@@ -265,28 +554,28 @@ func (o *TakeOptions) GeolocationLatitude(latitude float64) *TakeOptions {
 // GeolocationLatitude sets geolocation longitude for the request.
 // Both latitude and longitude are required if one of them is set.
 func (o *TakeOptions) GeolocationLongitude(longitude float64) *TakeOptions {
-	o.query.Add("geolocation_longitude", strconv.FormatFloat(longitude, byte('f'), -1, 64))
+	o.query.Set("geolocation_longitude", strconv.FormatFloat(longitude, byte('f'), -1, 64))
 
 	return o
 }
 
 // GeolocationAccuracy sets the geolocation accuracy in meters.
 func (o *TakeOptions) GeolocationAccuracy(accuracy int) *TakeOptions {
-	o.query.Add("geolocation_accuracy", strconv.Itoa(accuracy))
+	o.query.Set("geolocation_accuracy", strconv.Itoa(accuracy))
 
 	return o
 }
 
 // BlockAds blocks ads.
 func (o *TakeOptions) BlockAds(blockAds bool) *TakeOptions {
-	o.query.Add("block_ads", strconv.FormatBool(blockAds))
+	o.query.Set("block_ads", strconv.FormatBool(blockAds))
 
 	return o
 }
 
 // BlockTrackers blocks trackers.
 func (o *TakeOptions) BlockTrackers(blockTrackers bool) *TakeOptions {
-	o.query.Add("block_trackers", strconv.FormatBool(blockTrackers))
+	o.query.Set("block_trackers", strconv.FormatBool(blockTrackers))
 
 	return o
 }
@@ -300,11 +589,10 @@ func (o *TakeOptions) BlockRequests(blockRequests ...string) *TakeOptions {
 	return o
 }
 
-// BlockResources blocks loading resources by type.
-// Available resource types are: "document", "stylesheet", "image", "media", "font", "script", "texttrack", "xhr", "fetch", "eventsource", "websocket", "manifest", "other".
-func (o *TakeOptions) BlockResources(blockResources ...string) *TakeOptions {
+// BlockResources blocks loading resources by type, e.g. ResourceTypeFont or ResourceTypeImage.
+func (o *TakeOptions) BlockResources(blockResources ...ResourceType) *TakeOptions {
 	for _, blockResource := range blockResources {
-		o.query.Add("block_resources", blockResource)
+		o.query.Add("block_resources", string(blockResource))
 	}
 
 	return o
@@ -312,35 +600,35 @@ func (o *TakeOptions) BlockResources(blockResources ...string) *TakeOptions {
 
 // Cache allows to cache the screenshot.
 func (o *TakeOptions) Cache(cache bool) *TakeOptions {
-	o.query.Add("cache", strconv.FormatBool(cache))
+	o.query.Set("cache", strconv.FormatBool(cache))
 
 	return o
 }
 
 // CacheTTL sets cache TTL.
 func (o *TakeOptions) CacheTTL(cacheTTL int) *TakeOptions {
-	o.query.Add("cache_ttl", strconv.Itoa(cacheTTL))
+	o.query.Set("cache_ttl", strconv.Itoa(cacheTTL))
 
 	return o
 }
 
 // CacheTTL sets cache key.
 func (o *TakeOptions) CacheKey(cacheKey string) *TakeOptions {
-	o.query.Add("cache_key", cacheKey)
+	o.query.Set("cache_key", cacheKey)
 
 	return o
 }
 
 // UserAgent sets a user agent for the request.
 func (o *TakeOptions) UserAgent(userAgent string) *TakeOptions {
-	o.query.Add("user_agent", userAgent)
+	o.query.Set("user_agent", userAgent)
 
 	return o
 }
 
 // Authorization sets an authorization header for the request.
 func (o *TakeOptions) Authorization(authorization string) *TakeOptions {
-	o.query.Add("authorization", authorization)
+	o.query.Set("authorization", authorization)
 
 	return o
 }
@@ -366,81 +654,82 @@ func (o *TakeOptions) Headers(headers ...string) *TakeOptions {
 // TimeZone sets time zone for the request.
 // Available time zones are: "America/Santiago", "Asia/Shanghai", "Europe/Berlin", "America/Guayaquil", "Europe/Madrid", "Pacific/Majuro", "Asia/Kuala_Lumpur", "Pacific/Auckland", "Europe/Lisbon", "Europe/Kiev", "Asia/Tashkent", "Europe/London".
 func (o *TakeOptions) TimeZone(timeZone string) *TakeOptions {
-	o.query.Add("time_zone", timeZone)
+	o.query.Set("time_zone", timeZone)
 
 	return o
 }
 
 // Delay sets delay.
 func (o *TakeOptions) Delay(delay int) *TakeOptions {
-	o.query.Add("delay", strconv.Itoa(delay))
+	o.query.Set("delay", strconv.Itoa(delay))
 
 	return o
 }
 
 // Timeout sets timeout.
 func (o *TakeOptions) Timeout(timeout int) *TakeOptions {
-	o.query.Add("timeout", strconv.Itoa(timeout))
+	o.query.Set("timeout", strconv.Itoa(timeout))
 
 	return o
 }
 
 // WaitUntil waits until an event occurred before taking a screenshot or rendering HTML or PDF.
-func (o *TakeOptions) WaitUntil(events ...string) *TakeOptions {
+func (o *TakeOptions) WaitUntil(events ...WaitUntilEvent) *TakeOptions {
 	for _, event := range events {
-		o.query.Add("wait_until", event)
+		o.query.Add("wait_until", string(event))
 	}
 	return o
 }
 
 // WaitForSelector waits until the element appears in DOM.
 func (o *TakeOptions) WaitForSelector(selector string) *TakeOptions {
-	o.query.Add("wait_for_selector", selector)
+	o.query.Set("wait_for_selector", selector)
 	return o
 }
 
-// WaitForSelectorAlgorithm sets the algorithm for waiting for selectors.
-func (o *TakeOptions) WaitForSelectorAlgorithm(algorithm string) *TakeOptions {
-	o.query.Add("wait_for_selector_algorithm", algorithm)
+// WaitForSelectorAlgorithm sets the algorithm for waiting for selectors, e.g. SelectorAlgorithmAtLeastOne.
+func (o *TakeOptions) WaitForSelectorAlgorithm(algorithm SelectorAlgorithm) *TakeOptions {
+	o.query.Set("wait_for_selector_algorithm", string(algorithm))
+
 	return o
 }
 
 // NavigationTimeout sets the timeout for navigation.
 func (o *TakeOptions) NavigationTimeout(timeout int) *TakeOptions {
-	o.query.Add("navigation_timeout", strconv.Itoa(timeout))
+	o.query.Set("navigation_timeout", strconv.Itoa(timeout))
 	return o
 }
 
 // CaptureBeyondViewport controls whether to capture beyond the viewport.
 func (o *TakeOptions) CaptureBeyondViewport(capture bool) *TakeOptions {
-	o.query.Add("capture_beyond_viewport", strconv.FormatBool(capture))
+	o.query.Set("capture_beyond_viewport", strconv.FormatBool(capture))
 	return o
 }
 
 // FullPageScroll controls whether to scroll to the bottom of the page and back to the top.
 func (o *TakeOptions) FullPageScroll(scroll bool) *TakeOptions {
-	o.query.Add("full_page_scroll", strconv.FormatBool(scroll))
+	o.query.Set("full_page_scroll", strconv.FormatBool(scroll))
 
 	return o
 }
 
 // FullPageScrollDelay sets the delay for full page scrolling.
 func (o *TakeOptions) FullPageScrollDelay(delay int) *TakeOptions {
-	o.query.Add("full_page_scroll_delay", strconv.Itoa(delay))
+	o.query.Set("full_page_scroll_delay", strconv.Itoa(delay))
 
 	return o
 }
 
 // FullPageScrollBy sets how much to scroll by for full page screenshots.
 func (o *TakeOptions) FullPageScrollBy(pixels int) *TakeOptions {
-	o.query.Add("full_page_scroll_by", strconv.Itoa(pixels))
+	o.query.Set("full_page_scroll_by", strconv.Itoa(pixels))
 
 	return o
 }
 
 // FullPageMaxHeight sets the maximum height for full page screenshots.
 func (o *TakeOptions) FullPageMaxHeight(height int) *TakeOptions {
-	o.query.Add("full_page_max_height", strconv.Itoa(height))
+	o.query.Set("full_page_max_height", strconv.Itoa(height))
 
 	return o
 }
@@ -456,439 +745,447 @@ func (o *TakeOptions) HideSelectors(selectors ...string) *TakeOptions {
 
 // Click specifies a selector to click before taking the screenshot.
 func (o *TakeOptions) Click(selector string) *TakeOptions {
-	o.query.Add("click", selector)
+	o.query.Set("click", selector)
 
 	return o
 }
 
 // ScrollIntoView scrolls the page to ensure the given selector is in view.
 func (o *TakeOptions) ScrollIntoView(selector string) *TakeOptions {
-	o.query.Add("scroll_into_view", selector)
+	o.query.Set("scroll_into_view", selector)
 
 	return o
 }
 
 // ScrollIntoViewAdjustTop adjusts the top position after scrolling into view.
 func (o *TakeOptions) ScrollIntoViewAdjustTop(pixels int) *TakeOptions {
-	o.query.Add("scroll_into_view_adjust_top", strconv.Itoa(pixels))
+	o.query.Set("scroll_into_view_adjust_top", strconv.Itoa(pixels))
 
 	return o
 }
 
 // DarkMode sets the dark mode for the screenshot.
 func (o *TakeOptions) DarkMode(enabled bool) *TakeOptions {
-	o.query.Add("dark_mode", strconv.FormatBool(enabled))
+	o.query.Set("dark_mode", strconv.FormatBool(enabled))
 
 	return o
 }
 
 // ReducedMotion sets the reduced motion mode for the screenshot.
 func (o *TakeOptions) ReducedMotion(enabled bool) *TakeOptions {
-	o.query.Add("reduced_motion", strconv.FormatBool(enabled))
+	o.query.Set("reduced_motion", strconv.FormatBool(enabled))
 
 	return o
 }
 
 // MediaType sets the media type for the screenshot.
-func (o *TakeOptions) MediaType(mediaType string) *TakeOptions {
-	o.query.Add("media_type", mediaType)
+func (o *TakeOptions) MediaType(mediaType MediaType) *TakeOptions {
+	o.query.Set("media_type", string(mediaType))
 
 	return o
 }
 
 // ViewportMobile sets whether the meta viewport tag is taken into account.
 func (o *TakeOptions) ViewportMobile(mobile bool) *TakeOptions {
-	o.query.Add("viewport_mobile", strconv.FormatBool(mobile))
+	o.query.Set("viewport_mobile", strconv.FormatBool(mobile))
 
 	return o
 }
 
 // ViewportHasTouch sets whether the viewport supports touch events.
 func (o *TakeOptions) ViewportHasTouch(hasTouch bool) *TakeOptions {
-	o.query.Add("viewport_has_touch", strconv.FormatBool(hasTouch))
+	o.query.Set("viewport_has_touch", strconv.FormatBool(hasTouch))
 
 	return o
 }
 
 // ViewportLandscape sets whether the viewport is in landscape mode.
 func (o *TakeOptions) ViewportLandscape(landscape bool) *TakeOptions {
-	o.query.Add("viewport_landscape", strconv.FormatBool(landscape))
+	o.query.Set("viewport_landscape", strconv.FormatBool(landscape))
 
 	return o
 }
 
 // ViewportDevice sets the device for viewport emulation.
 func (o *TakeOptions) ViewportDevice(device string) *TakeOptions {
-	o.query.Add("viewport_device", device)
+	o.query.Set("viewport_device", device)
 
 	return o
 }
 
 // BlockCookieBanners blocks cookie banners and privacy notices.
 func (o *TakeOptions) BlockCookieBanners(block bool) *TakeOptions {
-	o.query.Add("block_cookie_banners", strconv.FormatBool(block))
+	o.query.Set("block_cookie_banners", strconv.FormatBool(block))
 
 	return o
 }
 
 // BlockBannersByHeuristics blocks banners using heuristics.
 func (o *TakeOptions) BlockBannersByHeuristics(block bool) *TakeOptions {
-	o.query.Add("block_banners_by_heuristics", strconv.FormatBool(block))
+	o.query.Set("block_banners_by_heuristics", strconv.FormatBool(block))
 
 	return o
 }
 
 // BlockChats blocks chat widgets.
 func (o *TakeOptions) BlockChats(block bool) *TakeOptions {
-	o.query.Add("block_chats", strconv.FormatBool(block))
+	o.query.Set("block_chats", strconv.FormatBool(block))
 
 	return o
 }
 
 // BypassCSP bypasses Content Security Policy.
 func (o *TakeOptions) BypassCSP(bypass bool) *TakeOptions {
-	o.query.Add("bypass_csp", strconv.FormatBool(bypass))
+	o.query.Set("bypass_csp", strconv.FormatBool(bypass))
 
 	return o
 }
 
 // Proxy sets a custom proxy for the request.
 func (o *TakeOptions) Proxy(proxyURL string) *TakeOptions {
-	o.query.Add("proxy", proxyURL)
+	o.query.Set("proxy", proxyURL)
 
 	return o
 }
 
 // IPCountryCode sets the country for IP-based geolocation.
 func (o *TakeOptions) IPCountryCode(countryCode string) *TakeOptions {
-	o.query.Add("ip_country_code", countryCode)
+	o.query.Set("ip_country_code", countryCode)
 
 	return o
 }
 
 // ResponseType sets the type of response to return.
-func (o *TakeOptions) ResponseType(responseType string) *TakeOptions {
-	o.query.Add("response_type", responseType)
+func (o *TakeOptions) ResponseType(responseType ResponseType) *TakeOptions {
+	o.query.Set("response_type", string(responseType))
 
 	return o
 }
 
 // Store enables storing the screenshot in S3-compatible storage.
 func (o *TakeOptions) Store(store bool) *TakeOptions {
-	o.query.Add("store", strconv.FormatBool(store))
+	o.query.Set("store", strconv.FormatBool(store))
 
 	return o
 }
 
 // StoragePath sets the storage path for the screenshot.
 func (o *TakeOptions) StoragePath(path string) *TakeOptions {
-	o.query.Add("storage_path", path)
+	o.query.Set("storage_path", path)
 
 	return o
 }
 
 // StorageACL sets the ACL for the stored screenshot.
-func (o *TakeOptions) StorageACL(acl string) *TakeOptions {
-	o.query.Add("storage_acl", acl)
+// Don't call it to use the default ACL of the bucket.
+func (o *TakeOptions) StorageACL(acl StorageACL) *TakeOptions {
+	o.query.Set("storage_acl", string(acl))
 
 	return o
 }
 
 // StorageReturnLocation enables returning the storage location.
 func (o *TakeOptions) StorageReturnLocation(returnLocation bool) *TakeOptions {
-	o.query.Add("storage_return_location", strconv.FormatBool(returnLocation))
+	o.query.Set("storage_return_location", strconv.FormatBool(returnLocation))
 
 	return o
 }
 
 // Async enables asynchronous execution of the request.
 func (o *TakeOptions) Async(async bool) *TakeOptions {
-	o.query.Add("async", strconv.FormatBool(async))
+	o.query.Set("async", strconv.FormatBool(async))
 
 	return o
 }
 
 // WebhookURL sets the URL for the webhook.
 func (o *TakeOptions) WebhookURL(url string) *TakeOptions {
-	o.query.Add("webhook_url", url)
+	o.query.Set("webhook_url", url)
 
 	return o
 }
 
 // WebhookSign controls whether to sign the webhook request body.
 func (o *TakeOptions) WebhookSign(sign bool) *TakeOptions {
-	o.query.Add("webhook_sign", strconv.FormatBool(sign))
+	o.query.Set("webhook_sign", strconv.FormatBool(sign))
 
 	return o
 }
 
 // WebhookErrors enables error details in webhook requests.
 func (o *TakeOptions) WebhookErrors(enable bool) *TakeOptions {
-	o.query.Add("webhook_errors", strconv.FormatBool(enable))
+	o.query.Set("webhook_errors", strconv.FormatBool(enable))
 
 	return o
 }
 
 // RequestGPURendering requests GPU rendering for the screenshot.
 func (o *TakeOptions) RequestGPURendering(request bool) *TakeOptions {
-	o.query.Add("request_gpu_rendering", strconv.FormatBool(request))
+	o.query.Set("request_gpu_rendering", strconv.FormatBool(request))
 
 	return o
 }
 
 // IncludeShadowDOM includes shadow DOM elements in the content.
 func (o *TakeOptions) IncludeShadowDOM(include bool) *TakeOptions {
-	o.query.Add("include_shadow_dom", strconv.FormatBool(include))
+	o.query.Set("include_shadow_dom", strconv.FormatBool(include))
 
 	return o
 }
 
 // AttachmentName sets the attachment name for the response.
 func (o *TakeOptions) AttachmentName(name string) *TakeOptions {
-	o.query.Add("attachment_name", name)
+	o.query.Set("attachment_name", name)
 
 	return o
 }
 
 // ExternalIdentifier sets an external identifier for the request.
 func (o *TakeOptions) ExternalIdentifier(identifier string) *TakeOptions {
-	o.query.Add("external_identifier", identifier)
+	o.query.Set("external_identifier", identifier)
 
 	return o
 }
 
 // FailIfGPURenderingFails forces the request to fail if GPU rendering fails.
 func (o *TakeOptions) FailIfGPURenderingFails(fail bool) *TakeOptions {
-	o.query.Add("fail_if_gpu_rendering_fails", strconv.FormatBool(fail))
+	o.query.Set("fail_if_gpu_rendering_fails", strconv.FormatBool(fail))
 
 	return o
 }
 
 // MetadataImageSize enables returning the actual image size metadata.
 func (o *TakeOptions) MetadataImageSize(enable bool) *TakeOptions {
-	o.query.Add("metadata_image_size", strconv.FormatBool(enable))
+	o.query.Set("metadata_image_size", strconv.FormatBool(enable))
 
 	return o
 }
 
 // MetadataFonts enables returning the fonts used by the website.
 func (o *TakeOptions) MetadataFonts(enable bool) *TakeOptions {
-	o.query.Add("metadata_fonts", strconv.FormatBool(enable))
+	o.query.Set("metadata_fonts", strconv.FormatBool(enable))
 
 	return o
 }
 
 // MetadataOpenGraph enables returning the Open Graph metadata.
 func (o *TakeOptions) MetadataOpenGraph(enable bool) *TakeOptions {
-	o.query.Add("metadata_open_graph", strconv.FormatBool(enable))
+	o.query.Set("metadata_open_graph", strconv.FormatBool(enable))
 
 	return o
 }
 
 // MetadataPageTitle enables returning the page title.
 func (o *TakeOptions) MetadataPageTitle(enable bool) *TakeOptions {
-	o.query.Add("metadata_page_title", strconv.FormatBool(enable))
+	o.query.Set("metadata_page_title", strconv.FormatBool(enable))
 
 	return o
 }
 
 // MetadataHTTPResponseHeaders enables returning the HTTP response headers.
 func (o *TakeOptions) MetadataHTTPResponseHeaders(enable bool) *TakeOptions {
-	o.query.Add("metadata_http_response_headers", strconv.FormatBool(enable))
+	o.query.Set("metadata_http_response_headers", strconv.FormatBool(enable))
 
 	return o
 }
 
 // MetadataHTTPResponseStatusCode enables returning the HTTP response status code.
 func (o *TakeOptions) MetadataHTTPResponseStatusCode(enable bool) *TakeOptions {
-	o.query.Add("metadata_http_response_status_code", strconv.FormatBool(enable))
+	o.query.Set("metadata_http_response_status_code", strconv.FormatBool(enable))
 
 	return o
 }
 
 // MetadataContent enables returning the content of the page.
 func (o *TakeOptions) MetadataContent(enable bool) *TakeOptions {
-	o.query.Add("metadata_content", strconv.FormatBool(enable))
+	o.query.Set("metadata_content", strconv.FormatBool(enable))
+
+	return o
+}
+
+// MetadataContentFormat sets the format of the returned page content, ContentFormatHTML or ContentFormatMarkdown.
+func (o *TakeOptions) MetadataContentFormat(format ContentFormat) *TakeOptions {
+	o.query.Set("metadata_content_format", string(format))
 
 	return o
 }
 
 // OpenAIAPIKey sets the OpenAI API key for vision integration.
 func (o *TakeOptions) OpenAIAPIKey(key string) *TakeOptions {
-	o.query.Add("openai_api_key", key)
+	o.query.Set("openai_api_key", key)
 
 	return o
 }
 
 // VisionPrompt sets the prompt for OpenAI vision integration.
 func (o *TakeOptions) VisionPrompt(prompt string) *TakeOptions {
-	o.query.Add("vision_prompt", prompt)
+	o.query.Set("vision_prompt", prompt)
 
 	return o
 }
 
 // VisionMaxTokens sets the maximum number of tokens for OpenAI vision integration.
 func (o *TakeOptions) VisionMaxTokens(tokens int) *TakeOptions {
-	o.query.Add("vision_max_tokens", strconv.Itoa(tokens))
+	o.query.Set("vision_max_tokens", strconv.Itoa(tokens))
 
 	return o
 }
 
 // FailIfContentContains forces the request to fail if the specified text is matched on the page.
 func (o *TakeOptions) FailIfContentContains(text string) *TakeOptions {
-	o.query.Add("fail_if_content_contains", text)
+	o.query.Set("fail_if_content_contains", text)
 
 	return o
 }
 
 // FailIfContentMissing forces the request to fail if the specified text is missing on the page.
 func (o *TakeOptions) FailIfContentMissing(text string) *TakeOptions {
-	o.query.Add("fail_if_content_missing", text)
+	o.query.Set("fail_if_content_missing", text)
 
 	return o
 }
 
 // FailIfRequestFailed forces the request to fail if any network request fails during page loading.
 func (o *TakeOptions) FailIfRequestFailed(pattern string) *TakeOptions {
-	o.query.Add("fail_if_request_failed", pattern)
+	o.query.Set("fail_if_request_failed", pattern)
 
 	return o
 }
 
 // PDFPrintBackground sets whether to print background graphics in PDF.
 func (o *TakeOptions) PDFPrintBackground(pdfPrintBackground bool) *TakeOptions {
-	o.query.Add("pdf_print_background", strconv.FormatBool(pdfPrintBackground))
+	o.query.Set("pdf_print_background", strconv.FormatBool(pdfPrintBackground))
 	return o
 }
 
 // PDFFitOnePage tries to fit the website on one page for PDF output.
 func (o *TakeOptions) PDFFitOnePage(pdfFitOnePage bool) *TakeOptions {
-	o.query.Add("pdf_fit_one_page", strconv.FormatBool(pdfFitOnePage))
+	o.query.Set("pdf_fit_one_page", strconv.FormatBool(pdfFitOnePage))
 	return o
 }
 
 // PDFLandscape sets PDF orientation to landscape.
 func (o *TakeOptions) PDFLandscape(pdfLandscape bool) *TakeOptions {
-	o.query.Add("pdf_landscape", strconv.FormatBool(pdfLandscape))
+	o.query.Set("pdf_landscape", strconv.FormatBool(pdfLandscape))
 	return o
 }
 
 // PDFPaperFormat specifies the paper format for PDF output.
-func (o *TakeOptions) PDFPaperFormat(format string) *TakeOptions {
-	o.query.Add("pdf_paper_format", format)
+func (o *TakeOptions) PDFPaperFormat(format PaperFormat) *TakeOptions {
+	o.query.Set("pdf_paper_format", string(format))
 	return o
 }
 
 // PDFMargin sets the margin for PDF output.
 func (o *TakeOptions) PDFMargin(margin string) *TakeOptions {
-	o.query.Add("pdf_margin", margin)
+	o.query.Set("pdf_margin", margin)
 	return o
 }
 
 // PDFMarginTop sets the top margin for PDF output.
 func (o *TakeOptions) PDFMarginTop(margin string) *TakeOptions {
-	o.query.Add("pdf_margin_top", margin)
+	o.query.Set("pdf_margin_top", margin)
 	return o
 }
 
 // PDFMarginRight sets the right margin for PDF output.
 func (o *TakeOptions) PDFMarginRight(margin string) *TakeOptions {
-	o.query.Add("pdf_margin_right", margin)
+	o.query.Set("pdf_margin_right", margin)
 	return o
 }
 
 // PDFMarginBottom sets the bottom margin for PDF output.
 func (o *TakeOptions) PDFMarginBottom(margin string) *TakeOptions {
-	o.query.Add("pdf_margin_bottom", margin)
+	o.query.Set("pdf_margin_bottom", margin)
 	return o
 }
 
 // PDFMarginLeft sets the left margin for PDF output.
 func (o *TakeOptions) PDFMarginLeft(margin string) *TakeOptions {
-	o.query.Add("pdf_margin_left", margin)
+	o.query.Set("pdf_margin_left", margin)
 	return o
 }
 
 // ClipX sets the x coordinate of the area to clip.
 func (o *TakeOptions) ClipX(x int) *TakeOptions {
-	o.query.Add("clip_x", strconv.Itoa(x))
+	o.query.Set("clip_x", strconv.Itoa(x))
 	return o
 }
 
 // ClipY sets the y coordinate of the area to clip.
 func (o *TakeOptions) ClipY(y int) *TakeOptions {
-	o.query.Add("clip_y", strconv.Itoa(y))
+	o.query.Set("clip_y", strconv.Itoa(y))
 	return o
 }
 
 // ClipWidth sets the width of the area to clip.
 func (o *TakeOptions) ClipWidth(width int) *TakeOptions {
-	o.query.Add("clip_width", strconv.Itoa(width))
+	o.query.Set("clip_width", strconv.Itoa(width))
 	return o
 }
 
 // ClipHeight sets the height of the area to clip.
 func (o *TakeOptions) ClipHeight(height int) *TakeOptions {
-	o.query.Add("clip_height", strconv.Itoa(height))
+	o.query.Set("clip_height", strconv.Itoa(height))
 	return o
 }
 
 // FullPageAlgorithm sets the algorithm for full page screenshots.
-func (o *TakeOptions) FullPageAlgorithm(algorithm string) *TakeOptions {
-	o.query.Add("full_page_algorithm", algorithm)
+func (o *TakeOptions) FullPageAlgorithm(algorithm FullPageAlgorithm) *TakeOptions {
+	o.query.Set("full_page_algorithm", string(algorithm))
 	return o
 }
 
 // SelectorScrollIntoView controls scrolling behavior when taking element screenshots.
 func (o *TakeOptions) SelectorScrollIntoView(enable bool) *TakeOptions {
-	o.query.Add("selector_scroll_into_view", strconv.FormatBool(enable))
+	o.query.Set("selector_scroll_into_view", strconv.FormatBool(enable))
 	return o
 }
 
 // IgnoreHostErrors allows taking screenshots even when site returns error status codes.
 func (o *TakeOptions) IgnoreHostErrors(ignore bool) *TakeOptions {
-	o.query.Add("ignore_host_errors", strconv.FormatBool(ignore))
+	o.query.Set("ignore_host_errors", strconv.FormatBool(ignore))
 	return o
 }
 
 // ErrorOnClickSelectorNotFound controls error behavior when click selector is not found.
 func (o *TakeOptions) ErrorOnClickSelectorNotFound(errorOn bool) *TakeOptions {
-	o.query.Add("error_on_click_selector_not_found", strconv.FormatBool(errorOn))
+	o.query.Set("error_on_click_selector_not_found", strconv.FormatBool(errorOn))
 	return o
 }
 
 // StorageEndpoint sets custom S3-compatible storage endpoint.
 func (o *TakeOptions) StorageEndpoint(endpoint string) *TakeOptions {
-	o.query.Add("storage_endpoint", endpoint)
+	o.query.Set("storage_endpoint", endpoint)
 	return o
 }
 
 // StorageAccessKeyID sets storage access key ID.
 func (o *TakeOptions) StorageAccessKeyID(keyID string) *TakeOptions {
-	o.query.Add("storage_access_key_id", keyID)
+	o.query.Set("storage_access_key_id", keyID)
 	return o
 }
 
 // StorageSecretAccessKey sets storage secret access key.
 func (o *TakeOptions) StorageSecretAccessKey(key string) *TakeOptions {
-	o.query.Add("storage_secret_access_key", key)
+	o.query.Set("storage_secret_access_key", key)
 	return o
 }
 
 // StorageBucket sets storage bucket name.
 func (o *TakeOptions) StorageBucket(bucket string) *TakeOptions {
-	o.query.Add("storage_bucket", bucket)
+	o.query.Set("storage_bucket", bucket)
 	return o
 }
 
 // StorageClass sets storage class for the object.
 func (o *TakeOptions) StorageClass(class string) *TakeOptions {
-	o.query.Add("storage_class", class)
+	o.query.Set("storage_class", class)
 	return o
 }
 
 // MetadataIcon enables returning the favicon metadata.
 func (o *TakeOptions) MetadataIcon(enable bool) *TakeOptions {
-	o.query.Add("metadata_icon", strconv.FormatBool(enable))
+	o.query.Set("metadata_icon", strconv.FormatBool(enable))
 	return o
 }
@@ -0,0 +1,69 @@
+package gosdk
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ClientOption configures the API client.
+type ClientOption func(*Client) error
+
+// WithBaseURL sets the base URL of the API, e.g. a regional endpoint or an internal proxy.
+// Defaults to "https://api.screenshotone.com".
+func WithBaseURL(baseURL string) ClientOption {
+	return func(client *Client) error {
+		u, err := url.Parse(baseURL)
+		if err != nil {
+			return fmt.Errorf("failed to parse base URL \"%s\": %w", baseURL, err)
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("base URL \"%s\" must be absolute", baseURL)
+		}
+		if u.RawQuery != "" || u.Fragment != "" {
+			return fmt.Errorf("base URL \"%s\" must not contain a query or a fragment", baseURL)
+		}
+
+		client.baseURL = strings.TrimRight(baseURL, "/")
+
+		return nil
+	}
+}
+
+// WithHTTPClient sets a custom HTTP client for executing requests.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(client *Client) error {
+		if httpClient == nil {
+			return fmt.Errorf("HTTP client is required")
+		}
+
+		client.httpClient = httpClient
+
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header for requests to the API.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(client *Client) error {
+		client.userAgent = userAgent
+
+		return nil
+	}
+}
+
+// WithDefaultOptions sets options applied to every request.
+// Options set on the request take precedence over the default ones.
+// The options are copied, changing them later does not affect the client.
+func WithDefaultOptions(options *TakeOptions) ClientOption {
+	return func(client *Client) error {
+		if options == nil {
+			return fmt.Errorf("default options are required")
+		}
+
+		client.defaultOptions = options.Clone()
+
+		return nil
+	}
+}
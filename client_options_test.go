@@ -0,0 +1,69 @@
+package gosdk_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	screenshots "github.com/screenshotone/gosdk"
+)
+
+func TestWithBaseURLGeneratesURL(t *testing.T) {
+	client, err := screenshots.NewClient("test-key", "", screenshots.WithBaseURL("http://localhost:8080/screenshotone/"))
+	ok(t, err)
+
+	u, err := client.GenerateUnsignedTakeURL(screenshots.NewTakeOptions("https://example.com"))
+	ok(t, err)
+
+	equals(t, "http://localhost:8080/screenshotone/take?access_key=test-key&url=https%3A%2F%2Fexample.com", u.String())
+}
+
+func TestWithBaseURLRejectsInvalidURL(t *testing.T) {
+	_, err := screenshots.NewClient("test-key", "", screenshots.WithBaseURL("localhost"))
+	errorred(t, err, "must be absolute")
+
+	_, err = screenshots.NewClient("test-key", "", screenshots.WithBaseURL("https://example.com/?a=b"))
+	errorred(t, err, "must not contain a query")
+}
+
+func TestWithHTTPClientRejectsNil(t *testing.T) {
+	_, err := screenshots.NewClient("test-key", "", screenshots.WithHTTPClient(nil))
+	errorred(t, err, "HTTP client is required")
+}
+
+func TestTakeUsesClientOptions(t *testing.T) {
+	roundTripper := &mockRoundTripper{statusCode: http.StatusOK}
+
+	client, err := screenshots.NewClient("test-key", "test-secret",
+		screenshots.WithHTTPClient(&http.Client{Transport: roundTripper}),
+		screenshots.WithBaseURL("https://eu.example.com"),
+		screenshots.WithUserAgent("test-agent/1.0"),
+	)
+	ok(t, err)
+
+	_, err = client.Take(context.Background(), screenshots.NewTakeOptions("https://example.com"))
+	ok(t, err)
+
+	equals(t, "eu.example.com", roundTripper.request.URL.Host)
+	equals(t, "/take", roundTripper.request.URL.Path)
+	equals(t, "test-agent/1.0", roundTripper.request.Header.Get("User-Agent"))
+}
+
+func TestWithDefaultOptionsAppliesDefaults(t *testing.T) {
+	defaults := screenshots.NewTakeDefaults().Format("webp").BlockAds(true).Cookies("key=value")
+
+	client, err := screenshots.NewClient("test-key", "", screenshots.WithDefaultOptions(defaults))
+	ok(t, err)
+
+	u, err := client.GenerateUnsignedTakeURL(screenshots.NewTakeWithHTML("<h1>Hello</h1>").Format("png"))
+	ok(t, err)
+
+	equals(t, "https://api.screenshotone.com/take?access_key=test-key&block_ads=true&cookies=key%3Dvalue&format=png&html=%3Ch1%3EHello%3C%2Fh1%3E", u.String())
+
+	// the client keeps a copy of the defaults
+	defaults.BlockAds(false)
+
+	u, err = client.GenerateUnsignedTakeURL(screenshots.NewTakeWithHTML("<h1>Hello</h1>").Format("png"))
+	ok(t, err)
+	equals(t, "true", u.Query().Get("block_ads"))
+}
@@ -10,6 +10,7 @@ import (
 	"reflect"
 	"runtime"
 	"strings"
+	"sync"
 	"testing"
 
 	screenshots "github.com/screenshotone/gosdk"
@@ -208,6 +209,91 @@ func TestGenerateTakeURLRequiresSecretKey(t *testing.T) {
 	errorred(t, err, "secret key is required")
 }
 
+func TestGenerateTakeURLDoesNotModifyOptions(t *testing.T) {
+	options := screenshots.NewTakeOptions("https://example.com").Format("png")
+
+	first, err := screenshots.NewClient("first-key", "first-secret")
+	ok(t, err)
+	second, err := screenshots.NewClient("second-key", "")
+	ok(t, err)
+
+	_, err = first.GenerateTakeURL(options)
+	ok(t, err)
+
+	u, err := second.GenerateUnsignedTakeURL(options)
+	ok(t, err)
+
+	equals(t, "https://api.screenshotone.com/take?access_key=second-key&format=png&url=https%3A%2F%2Fexample.com", u.String())
+}
+
+func TestGenerateTakeURLIsSafeForConcurrentUse(t *testing.T) {
+	options := screenshots.NewTakeOptions("https://example.com").Format("png")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			accessKey := fmt.Sprintf("key-%d", i)
+			client, err := screenshots.NewClient(accessKey, "secret")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			u, err := client.GenerateTakeURL(options)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if u.Query().Get("access_key") != accessKey {
+				t.Errorf("expected access key %q, got %q", accessKey, u.Query().Get("access_key"))
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestCloneCopiesOptions(t *testing.T) {
+	template := screenshots.NewTakeOptions("https://example.com").Format("png").Cookies("key=value")
+
+	clone := template.Clone().Cookies("key1=value1").FullPage(true)
+
+	equals(t, "https://api.screenshotone.com/take?access_key=test-key&cookies=key%3Dvalue&format=png&url=https%3A%2F%2Fexample.com", mustGenerateUnsignedTakeURL(t, "test-key", template))
+	equals(t, "https://api.screenshotone.com/take?access_key=test-key&cookies=key%3Dvalue&cookies=key1%3Dvalue1&format=png&full_page=true&url=https%3A%2F%2Fexample.com", mustGenerateUnsignedTakeURL(t, "test-key", clone))
+}
+
+func TestScalarOptionsReplacePreviousValues(t *testing.T) {
+	options := screenshots.NewTakeOptions("https://example.com").
+		FullPage(true).
+		FullPage(false).
+		Format("png").
+		Format("webp").
+		Cookies("key=value").
+		Cookies("key1=value1")
+
+	equals(t, "https://api.screenshotone.com/take?access_key=test-key&cookies=key%3Dvalue&cookies=key1%3Dvalue1&format=webp&full_page=false&url=https%3A%2F%2Fexample.com", mustGenerateUnsignedTakeURL(t, "test-key", options))
+}
+
+func TestOptionAccessors(t *testing.T) {
+	options := screenshots.NewTakeOptions("https://example.com").
+		FullPage(true).
+		HideSelectors(".banner", ".popup")
+
+	equals(t, true, options.Has("full_page"))
+	equals(t, "true", options.Get("full_page"))
+	equals(t, []string{".banner", ".popup"}, options.Values("hide_selectors"))
+	equals(t, false, options.Has("format"))
+	equals(t, "", options.Get("format"))
+
+	options.Unset("full_page").Unset("hide_selectors")
+
+	equals(t, false, options.Has("full_page"))
+	equals(t, []string(nil), options.Values("hide_selectors"))
+	equals(t, "https://api.screenshotone.com/take?access_key=test-key&url=https%3A%2F%2Fexample.com", mustGenerateUnsignedTakeURL(t, "test-key", options))
+}
+
 func TestTakeAcceptsOKStatusCode(t *testing.T) {
 	mockClient := &http.Client{
 		Transport: &mockRoundTripper{
@@ -220,10 +306,11 @@ func TestTakeAcceptsOKStatusCode(t *testing.T) {
 	ok(t, err)
 
 	options := screenshots.NewTakeOptions("https://example.com")
-	image, _, err := client.Take(context.Background(), options)
+	result, err := client.Take(context.Background(), options)
 	ok(t, err)
 
-	equals(t, "test image data", string(image))
+	equals(t, "test image data", string(result.Body))
+	equals(t, http.StatusOK, result.StatusCode)
 }
 
 func TestTakeAcceptsCreatedStatusCode(t *testing.T) {
@@ -238,10 +325,11 @@ func TestTakeAcceptsCreatedStatusCode(t *testing.T) {
 	ok(t, err)
 
 	options := screenshots.NewTakeOptions("https://example.com")
-	image, _, err := client.Take(context.Background(), options)
+	result, err := client.Take(context.Background(), options)
 	ok(t, err)
 
-	equals(t, "", string(image))
+	equals(t, "", string(result.Body))
+	equals(t, http.StatusCreated, result.StatusCode)
 }
 
 func TestTakeRejectsOtherStatusCodes(t *testing.T) {
@@ -256,10 +344,149 @@ func TestTakeRejectsOtherStatusCodes(t *testing.T) {
 	ok(t, err)
 
 	options := screenshots.NewTakeOptions("https://example.com")
-	_, _, err = client.Take(context.Background(), options)
+	_, err = client.Take(context.Background(), options)
 	errorred(t, err, "the server returned a response: 400 Bad Request")
 }
 
+func TestTakeReturnsResponseMetadata(t *testing.T) {
+	header := make(http.Header)
+	header.Set("Content-Type", "image/webp; charset=binary")
+	header.Set("Cache-Control", "max-age=3600")
+	header.Set("X-ScreenshotOne-Cache-Hit", "true")
+	header.Set("X-ScreenshotOne-Rendering-Time", "1250")
+
+	mockClient := &http.Client{
+		Transport: &mockRoundTripper{
+			statusCode: http.StatusOK,
+			body:       []byte("test image data"),
+			header:     header,
+		},
+	}
+
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", mockClient)
+	ok(t, err)
+
+	options := screenshots.NewTakeOptions("https://example.com").Format("webp")
+	result, err := client.Take(context.Background(), options)
+	ok(t, err)
+
+	equals(t, "image/webp", result.ContentType)
+	equals(t, "webp", result.Format)
+	equals(t, "max-age=3600", result.Header.Get("Cache-Control"))
+	equals(t, map[string]string{"cache-hit": "true", "rendering-time": "1250"}, result.Metadata)
+}
+
+func TestTakeDetectsFormatWithoutContentType(t *testing.T) {
+	mockClient := &http.Client{
+		Transport: &mockRoundTripper{
+			statusCode: http.StatusOK,
+			body:       []byte("%PDF-1.7\n"),
+		},
+	}
+
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", mockClient)
+	ok(t, err)
+
+	result, err := client.Take(context.Background(), screenshots.NewTakeOptions("https://example.com"))
+	ok(t, err)
+
+	equals(t, "application/pdf", result.ContentType)
+	equals(t, "pdf", result.Format)
+}
+
+func TestTakeBytesKeepsCompatibility(t *testing.T) {
+	mockClient := &http.Client{
+		Transport: &mockRoundTripper{
+			statusCode: http.StatusOK,
+			body:       []byte("test image data"),
+		},
+	}
+
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", mockClient)
+	ok(t, err)
+
+	image, _, err := client.TakeBytes(context.Background(), screenshots.NewTakeOptions("https://example.com"))
+	ok(t, err)
+
+	equals(t, "test image data", string(image))
+}
+
+func TestTakeToWritesImage(t *testing.T) {
+	header := make(http.Header)
+	header.Set("Content-Type", "application/pdf")
+
+	mockClient := &http.Client{
+		Transport: &mockRoundTripper{
+			statusCode: http.StatusOK,
+			body:       []byte("test pdf data"),
+			header:     header,
+		},
+	}
+
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", mockClient)
+	ok(t, err)
+
+	var out bytes.Buffer
+	result, err := client.TakeTo(context.Background(), &out, screenshots.NewTakeOptions("https://example.com").Format("pdf"))
+	ok(t, err)
+
+	equals(t, "test pdf data", out.String())
+	equals(t, "pdf", result.Format)
+	equals(t, []byte(nil), result.Body)
+}
+
+func TestTakeStreamReturnsBody(t *testing.T) {
+	header := make(http.Header)
+	header.Set("Content-Type", "video/mp4")
+
+	mockClient := &http.Client{
+		Transport: &mockRoundTripper{
+			statusCode: http.StatusOK,
+			body:       []byte("test video data"),
+			header:     header,
+		},
+	}
+
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", mockClient)
+	ok(t, err)
+
+	body, responseHeader, err := client.TakeStream(context.Background(), screenshots.NewTakeOptions("https://example.com"))
+	ok(t, err)
+	defer body.Close()
+
+	data, err := ioutil.ReadAll(body)
+	ok(t, err)
+
+	equals(t, "test video data", string(data))
+	equals(t, "video/mp4", responseHeader.Get("Content-Type"))
+}
+
+func TestTakeStreamRejectsOtherStatusCodes(t *testing.T) {
+	mockClient := &http.Client{
+		Transport: &mockRoundTripper{
+			statusCode: http.StatusBadRequest,
+			body:       []byte("bad request"),
+		},
+	}
+
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", mockClient)
+	ok(t, err)
+
+	_, _, err = client.TakeStream(context.Background(), screenshots.NewTakeOptions("https://example.com"))
+	errorred(t, err, "the server returned a response: 400 Bad Request")
+}
+
+// mustGenerateUnsignedTakeURL generates the unsigned take URL for the access key or fails the test.
+func mustGenerateUnsignedTakeURL(tb testing.TB, accessKey string, options *screenshots.TakeOptions) string {
+	client, err := screenshots.NewClient(accessKey, "")
+	ok(tb, err)
+
+	u, err := client.GenerateUnsignedTakeURL(options)
+	ok(tb, err)
+
+	return u.String()
+}
+
 // errorred fails the test if an err is nil or message is not found in the message string.
 func errorred(tb testing.TB, err error, message string) {
 	if err == nil {
@@ -298,13 +525,24 @@ func equals(tb testing.TB, exp, act interface{}) {
 type mockRoundTripper struct {
 	statusCode int
 	body       []byte
+	header     http.Header
+
+	// request is the last executed request
+	request *http.Request
 }
 
 func (m *mockRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	m.request = req
+
+	header := make(http.Header)
+	for name, values := range m.header {
+		header[name] = values
+	}
+
 	return &http.Response{
 		StatusCode: m.statusCode,
-		Status:     http.StatusText(m.statusCode),
+		Status:     fmt.Sprintf("%d %s", m.statusCode, http.StatusText(m.statusCode)),
 		Body:       ioutil.NopCloser(bytes.NewReader(m.body)),
-		Header:     make(http.Header),
+		Header:     header,
 	}, nil
 }
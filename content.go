@@ -0,0 +1,33 @@
+package gosdk
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// ExtractContent renders the page with the options and returns its content in the format,
+// ContentFormatHTML or ContentFormatMarkdown. The caller must close the returned reader.
+//
+// It requests the content metadata, so the same options can be used for screenshots and content,
+// and downloads the content by the returned URL.
+func (client *Client) ExtractContent(ctx context.Context, options *TakeOptions, format ContentFormat) (io.ReadCloser, error) {
+	if _, err := ParseContentFormat(string(format)); err != nil {
+		return nil, err
+	}
+
+	response, _, err := client.takeJSON(ctx, options.Clone().MetadataContent(true).MetadataContentFormat(format))
+	if err != nil {
+		return nil, err
+	}
+	if response.Metadata == nil || response.Metadata.Content == nil || response.Metadata.Content.URL == "" {
+		return nil, fmt.Errorf("the response has no content URL")
+	}
+
+	body, err := client.download(ctx, response.Metadata.Content.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download the content: %w", err)
+	}
+
+	return body, nil
+}
@@ -0,0 +1,70 @@
+package gosdk_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	screenshots "github.com/screenshotone/gosdk"
+)
+
+func TestExtractContentFollowsContentURL(t *testing.T) {
+	roundTripper := &hostRoundTripper{responses: map[string]*mockRoundTripper{
+		"api.screenshotone.com": {
+			statusCode: http.StatusOK,
+			body:       []byte(`{"metadata":{"content":{"url":"https://content.screenshotone.com/abc.md"}}}`),
+		},
+		"content.screenshotone.com": {
+			statusCode: http.StatusOK,
+			body:       []byte("# Example Domain"),
+		},
+	}}
+
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: roundTripper})
+	ok(t, err)
+
+	options := screenshots.NewTakeOptions("https://example.com").IncludeShadowDOM(true)
+	body, err := client.ExtractContent(context.Background(), options, screenshots.ContentFormatMarkdown)
+	ok(t, err)
+	defer body.Close()
+
+	content, err := ioutil.ReadAll(body)
+	ok(t, err)
+	equals(t, "# Example Domain", string(content))
+
+	query := roundTripper.responses["api.screenshotone.com"].request.URL.Query()
+	equals(t, "true", query.Get("metadata_content"))
+	equals(t, "markdown", query.Get("metadata_content_format"))
+	equals(t, "json", query.Get("response_type"))
+	equals(t, "true", query.Get("include_shadow_dom"))
+	equals(t, false, options.Has("metadata_content"))
+}
+
+func TestExtractContentFailsWithoutContentURL(t *testing.T) {
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: &mockRoundTripper{
+		statusCode: http.StatusOK,
+		body:       []byte(`{"metadata":{}}`),
+	}})
+	ok(t, err)
+
+	_, err = client.ExtractContent(context.Background(), screenshots.NewTakeOptions("https://example.com"), screenshots.ContentFormatHTML)
+	errorred(t, err, "the response has no content URL")
+}
+
+func TestExtractContentRejectsUnknownFormat(t *testing.T) {
+	client, err := screenshots.NewClient("test-key", "test-secret")
+	ok(t, err)
+
+	_, err = client.ExtractContent(context.Background(), screenshots.NewTakeOptions("https://example.com"), "text")
+	errorred(t, err, "unknown content format \"text\"")
+}
+
+// hostRoundTripper responds with the mock response of the request host.
+type hostRoundTripper struct {
+	responses map[string]*mockRoundTripper
+}
+
+func (m *hostRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return m.responses[req.URL.Host].RoundTrip(req)
+}
@@ -0,0 +1,392 @@
+package gosdk
+
+import "fmt"
+
+// Format is the response format of the take method.
+type Format string
+
+// Available response formats.
+const (
+	FormatPNG      Format = "png"
+	FormatJPEG     Format = "jpeg"
+	FormatJPG      Format = "jpg"
+	FormatWebP     Format = "webp"
+	FormatGIF      Format = "gif"
+	FormatJP2      Format = "jp2"
+	FormatTIFF     Format = "tiff"
+	FormatAVIF     Format = "avif"
+	FormatHEIF     Format = "heif"
+	FormatPDF      Format = "pdf"
+	FormatHTML     Format = "html"
+	FormatMarkdown Format = "markdown"
+
+	// Video formats available for animated screenshots, along with FormatGIF.
+	FormatMP4  Format = "mp4"
+	FormatWebM Format = "webm"
+	FormatMOV  Format = "mov"
+	FormatAVI  Format = "avi"
+)
+
+// WaitUntilEvent is the browser event to wait for before taking a screenshot or completing scripts.
+type WaitUntilEvent string
+
+// Available events to wait for.
+const (
+	WaitUntilLoad             WaitUntilEvent = "load"
+	WaitUntilDOMContentLoaded WaitUntilEvent = "domcontentloaded"
+	WaitUntilNetworkIdle0     WaitUntilEvent = "networkidle0"
+	WaitUntilNetworkIdle2     WaitUntilEvent = "networkidle2"
+)
+
+// ResourceType is the type of resources to block.
+type ResourceType string
+
+// Available resource types.
+const (
+	ResourceTypeDocument    ResourceType = "document"
+	ResourceTypeStylesheet  ResourceType = "stylesheet"
+	ResourceTypeImage       ResourceType = "image"
+	ResourceTypeMedia       ResourceType = "media"
+	ResourceTypeFont        ResourceType = "font"
+	ResourceTypeScript      ResourceType = "script"
+	ResourceTypeTextTrack   ResourceType = "texttrack"
+	ResourceTypeXHR         ResourceType = "xhr"
+	ResourceTypeFetch       ResourceType = "fetch"
+	ResourceTypeEventSource ResourceType = "eventsource"
+	ResourceTypeWebSocket   ResourceType = "websocket"
+	ResourceTypeManifest    ResourceType = "manifest"
+	ResourceTypeOther       ResourceType = "other"
+)
+
+// MediaType is the CSS media type to emulate.
+type MediaType string
+
+// Available media types.
+const (
+	MediaTypeScreen MediaType = "screen"
+	MediaTypePrint  MediaType = "print"
+)
+
+// ResponseType is the type of the take method response.
+type ResponseType string
+
+// Available response types.
+const (
+	ResponseTypeByFormat ResponseType = "by_format"
+	ResponseTypeEmpty    ResponseType = "empty"
+	ResponseTypeJSON     ResponseType = "json"
+)
+
+// ContentFormat is the format of the page content returned with MetadataContent.
+type ContentFormat string
+
+// Available content formats.
+const (
+	ContentFormatHTML     ContentFormat = "html"
+	ContentFormatMarkdown ContentFormat = "markdown"
+)
+
+// StorageACL is the access control list of the stored screenshot.
+// To use the default ACL of the bucket, don't set the option.
+type StorageACL string
+
+// Available storage ACLs.
+const (
+	StorageACLPublicRead StorageACL = "public-read"
+)
+
+// FullPageAlgorithm is the algorithm for full page screenshots.
+type FullPageAlgorithm string
+
+// Available full page algorithms.
+const (
+	FullPageAlgorithmDefault    FullPageAlgorithm = "default"
+	FullPageAlgorithmBySections FullPageAlgorithm = "by_sections"
+)
+
+// PaperFormat is the paper format for PDF output.
+type PaperFormat string
+
+// Available paper formats.
+const (
+	PaperLetter  PaperFormat = "letter"
+	PaperLegal   PaperFormat = "legal"
+	PaperTabloid PaperFormat = "tabloid"
+	PaperLedger  PaperFormat = "ledger"
+	PaperA0      PaperFormat = "a0"
+	PaperA1      PaperFormat = "a1"
+	PaperA2      PaperFormat = "a2"
+	PaperA3      PaperFormat = "a3"
+	PaperA4      PaperFormat = "a4"
+	PaperA5      PaperFormat = "a5"
+	PaperA6      PaperFormat = "a6"
+)
+
+// AnimateScenario is the scenario of an animated screenshot.
+type AnimateScenario string
+
+// Available animation scenarios.
+const (
+	// AnimateScenarioDefault records the page without interactions.
+	AnimateScenarioDefault AnimateScenario = "default"
+	// AnimateScenarioScroll scrolls the page while recording.
+	AnimateScenarioScroll AnimateScenario = "scroll"
+)
+
+// ScrollEasing is the easing function of scrolling in animated screenshots.
+type ScrollEasing string
+
+// Available scroll easing functions.
+const (
+	ScrollEasingLinear         ScrollEasing = "linear"
+	ScrollEasingEaseInQuad     ScrollEasing = "ease_in_quad"
+	ScrollEasingEaseOutQuad    ScrollEasing = "ease_out_quad"
+	ScrollEasingEaseInOutQuad  ScrollEasing = "ease_in_out_quad"
+	ScrollEasingEaseInCubic    ScrollEasing = "ease_in_cubic"
+	ScrollEasingEaseOutCubic   ScrollEasing = "ease_out_cubic"
+	ScrollEasingEaseInOutCubic ScrollEasing = "ease_in_out_cubic"
+	ScrollEasingEaseInQuart    ScrollEasing = "ease_in_quart"
+	ScrollEasingEaseOutQuart   ScrollEasing = "ease_out_quart"
+	ScrollEasingEaseInOutQuart ScrollEasing = "ease_in_out_quart"
+	ScrollEasingEaseInQuint    ScrollEasing = "ease_in_quint"
+	ScrollEasingEaseOutQuint   ScrollEasing = "ease_out_quint"
+	ScrollEasingEaseInOutQuint ScrollEasing = "ease_in_out_quint"
+)
+
+// SelectorAlgorithm is the algorithm for finding or waiting for selectors.
+type SelectorAlgorithm string
+
+// Available selector algorithms.
+const (
+	// SelectorAlgorithmCSS finds the element by the CSS selector, for the SelectorAlgorithm option.
+	SelectorAlgorithmCSS SelectorAlgorithm = "css"
+	// SelectorAlgorithmAtLeastOne waits until at least one of the selectors is found, for the WaitForSelectorAlgorithm option.
+	SelectorAlgorithmAtLeastOne SelectorAlgorithm = "at_least_one"
+	// SelectorAlgorithmAtLeastByCount waits until all the selectors are found, for the WaitForSelectorAlgorithm option.
+	SelectorAlgorithmAtLeastByCount SelectorAlgorithm = "at_least_by_count"
+)
+
+var (
+	formatValues = []string{
+		string(FormatPNG), string(FormatJPEG), string(FormatJPG), string(FormatWebP), string(FormatGIF), string(FormatJP2),
+		string(FormatTIFF), string(FormatAVIF), string(FormatHEIF), string(FormatPDF), string(FormatHTML), string(FormatMarkdown),
+	}
+	animateFormatValues     = []string{string(FormatMP4), string(FormatWebM), string(FormatMOV), string(FormatAVI), string(FormatGIF)}
+	qualityFormatValues     = []string{string(FormatJPEG), string(FormatJPG), string(FormatWebP)}
+	transparentFormatValues = []string{string(FormatPNG), string(FormatWebP)}
+	waitUntilValues         = []string{
+		string(WaitUntilLoad), string(WaitUntilDOMContentLoaded), string(WaitUntilNetworkIdle0), string(WaitUntilNetworkIdle2),
+	}
+	resourceTypeValues = []string{
+		string(ResourceTypeDocument), string(ResourceTypeStylesheet), string(ResourceTypeImage), string(ResourceTypeMedia),
+		string(ResourceTypeFont), string(ResourceTypeScript), string(ResourceTypeTextTrack), string(ResourceTypeXHR),
+		string(ResourceTypeFetch), string(ResourceTypeEventSource), string(ResourceTypeWebSocket), string(ResourceTypeManifest),
+		string(ResourceTypeOther),
+	}
+	mediaTypeValues         = []string{string(MediaTypeScreen), string(MediaTypePrint)}
+	responseTypeValues      = []string{string(ResponseTypeByFormat), string(ResponseTypeEmpty), string(ResponseTypeJSON)}
+	contentFormatValues     = []string{string(ContentFormatHTML), string(ContentFormatMarkdown)}
+	storageACLValues        = []string{string(StorageACLPublicRead)}
+	fullPageAlgorithmValues = []string{string(FullPageAlgorithmDefault), string(FullPageAlgorithmBySections)}
+	animateScenarioValues   = []string{string(AnimateScenarioDefault), string(AnimateScenarioScroll)}
+	scrollEasingValues      = []string{
+		string(ScrollEasingLinear), string(ScrollEasingEaseInQuad), string(ScrollEasingEaseOutQuad), string(ScrollEasingEaseInOutQuad),
+		string(ScrollEasingEaseInCubic), string(ScrollEasingEaseOutCubic), string(ScrollEasingEaseInOutCubic),
+		string(ScrollEasingEaseInQuart), string(ScrollEasingEaseOutQuart), string(ScrollEasingEaseInOutQuart),
+		string(ScrollEasingEaseInQuint), string(ScrollEasingEaseOutQuint), string(ScrollEasingEaseInOutQuint),
+	}
+	selectorAlgorithmValues = []string{
+		string(SelectorAlgorithmCSS), string(SelectorAlgorithmAtLeastOne), string(SelectorAlgorithmAtLeastByCount),
+	}
+	selectorOptionAlgorithmValues  = []string{string(SelectorAlgorithmCSS)}
+	waitForSelectorAlgorithmValues = []string{string(SelectorAlgorithmAtLeastOne), string(SelectorAlgorithmAtLeastByCount)}
+	paperFormatValues              = []string{
+		string(PaperLetter), string(PaperLegal), string(PaperTabloid), string(PaperLedger), string(PaperA0), string(PaperA1),
+		string(PaperA2), string(PaperA3), string(PaperA4), string(PaperA5), string(PaperA6),
+	}
+)
+
+// String returns the API value of the format.
+func (f Format) String() string {
+	return string(f)
+}
+
+// ParseFormat parses the API value of the format of screenshots, see ParseAnimateFormat for animated screenshots.
+func ParseFormat(value string) (Format, error) {
+	if err := parseEnum("format", value, formatValues); err != nil {
+		return "", err
+	}
+
+	return Format(value), nil
+}
+
+// ParseAnimateFormat parses the API value of the format of animated screenshots.
+func ParseAnimateFormat(value string) (Format, error) {
+	if err := parseEnum("animate format", value, animateFormatValues); err != nil {
+		return "", err
+	}
+
+	return Format(value), nil
+}
+
+// String returns the API value of the event.
+func (e WaitUntilEvent) String() string {
+	return string(e)
+}
+
+// ParseWaitUntilEvent parses the API value of the event.
+func ParseWaitUntilEvent(value string) (WaitUntilEvent, error) {
+	if err := parseEnum("wait until event", value, waitUntilValues); err != nil {
+		return "", err
+	}
+
+	return WaitUntilEvent(value), nil
+}
+
+// String returns the API value of the resource type.
+func (t ResourceType) String() string {
+	return string(t)
+}
+
+// ParseResourceType parses the API value of the resource type.
+func ParseResourceType(value string) (ResourceType, error) {
+	if err := parseEnum("resource type", value, resourceTypeValues); err != nil {
+		return "", err
+	}
+
+	return ResourceType(value), nil
+}
+
+// String returns the API value of the media type.
+func (t MediaType) String() string {
+	return string(t)
+}
+
+// ParseMediaType parses the API value of the media type.
+func ParseMediaType(value string) (MediaType, error) {
+	if err := parseEnum("media type", value, mediaTypeValues); err != nil {
+		return "", err
+	}
+
+	return MediaType(value), nil
+}
+
+// String returns the API value of the response type.
+func (t ResponseType) String() string {
+	return string(t)
+}
+
+// ParseResponseType parses the API value of the response type.
+func ParseResponseType(value string) (ResponseType, error) {
+	if err := parseEnum("response type", value, responseTypeValues); err != nil {
+		return "", err
+	}
+
+	return ResponseType(value), nil
+}
+
+// String returns the API value of the content format.
+func (f ContentFormat) String() string {
+	return string(f)
+}
+
+// ParseContentFormat parses the API value of the content format.
+func ParseContentFormat(value string) (ContentFormat, error) {
+	if err := parseEnum("content format", value, contentFormatValues); err != nil {
+		return "", err
+	}
+
+	return ContentFormat(value), nil
+}
+
+// String returns the API value of the ACL.
+func (acl StorageACL) String() string {
+	return string(acl)
+}
+
+// ParseStorageACL parses the API value of the ACL.
+func ParseStorageACL(value string) (StorageACL, error) {
+	if err := parseEnum("storage ACL", value, storageACLValues); err != nil {
+		return "", err
+	}
+
+	return StorageACL(value), nil
+}
+
+// String returns the API value of the algorithm.
+func (a FullPageAlgorithm) String() string {
+	return string(a)
+}
+
+// ParseFullPageAlgorithm parses the API value of the algorithm.
+func ParseFullPageAlgorithm(value string) (FullPageAlgorithm, error) {
+	if err := parseEnum("full page algorithm", value, fullPageAlgorithmValues); err != nil {
+		return "", err
+	}
+
+	return FullPageAlgorithm(value), nil
+}
+
+// String returns the API value of the paper format.
+func (f PaperFormat) String() string {
+	return string(f)
+}
+
+// ParsePaperFormat parses the API value of the paper format.
+func ParsePaperFormat(value string) (PaperFormat, error) {
+	if err := parseEnum("paper format", value, paperFormatValues); err != nil {
+		return "", err
+	}
+
+	return PaperFormat(value), nil
+}
+
+// String returns the API value of the scenario.
+func (s AnimateScenario) String() string {
+	return string(s)
+}
+
+// ParseAnimateScenario parses the API value of the scenario.
+func ParseAnimateScenario(value string) (AnimateScenario, error) {
+	if err := parseEnum("animate scenario", value, animateScenarioValues); err != nil {
+		return "", err
+	}
+
+	return AnimateScenario(value), nil
+}
+
+// String returns the API value of the easing function.
+func (e ScrollEasing) String() string {
+	return string(e)
+}
+
+// ParseScrollEasing parses the API value of the easing function.
+func ParseScrollEasing(value string) (ScrollEasing, error) {
+	if err := parseEnum("scroll easing", value, scrollEasingValues); err != nil {
+		return "", err
+	}
+
+	return ScrollEasing(value), nil
+}
+
+// String returns the API value of the algorithm.
+func (a SelectorAlgorithm) String() string {
+	return string(a)
+}
+
+// ParseSelectorAlgorithm parses the API value of the selector algorithm.
+func ParseSelectorAlgorithm(value string) (SelectorAlgorithm, error) {
+	if err := parseEnum("selector algorithm", value, selectorAlgorithmValues); err != nil {
+		return "", err
+	}
+
+	return SelectorAlgorithm(value), nil
+}
+
+func parseEnum(name, value string, values []string) error {
+	if !contains(values, value) {
+		return fmt.Errorf("unknown %s %q, must be one of %q", name, value, values)
+	}
+
+	return nil
+}
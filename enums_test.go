@@ -0,0 +1,79 @@
+package gosdk_test
+
+import (
+	"testing"
+
+	screenshots "github.com/screenshotone/gosdk"
+)
+
+func TestTypedOptionsGenerateURL(t *testing.T) {
+	options := screenshots.NewTakeOptions("https://example.com").
+		Format(screenshots.FormatPDF).
+		WaitUntil(screenshots.WaitUntilNetworkIdle0).
+		BlockResources(screenshots.ResourceTypeFont, screenshots.ResourceTypeImage).
+		MediaType(screenshots.MediaTypePrint).
+		PDFPaperFormat(screenshots.PaperA4)
+
+	equals(t, "https://api.screenshotone.com/take?access_key=test-key&block_resources=font&block_resources=image&format=pdf&media_type=print&pdf_paper_format=a4&url=https%3A%2F%2Fexample.com&wait_until=networkidle0", mustGenerateUnsignedTakeURL(t, "test-key", options))
+}
+
+func TestParseEnums(t *testing.T) {
+	format, err := screenshots.ParseFormat("webp")
+	ok(t, err)
+	equals(t, screenshots.FormatWebP, format)
+	equals(t, "webp", format.String())
+
+	_, err = screenshots.ParseFormat("bmp")
+	errorred(t, err, "unknown format \"bmp\"")
+
+	_, err = screenshots.ParseFormat("mp4")
+	errorred(t, err, "unknown format \"mp4\"")
+
+	animateFormat, err := screenshots.ParseAnimateFormat("mp4")
+	ok(t, err)
+	equals(t, screenshots.FormatMP4, animateFormat)
+
+	_, err = screenshots.ParseAnimateFormat("png")
+	errorred(t, err, "unknown animate format \"png\"")
+
+	event, err := screenshots.ParseWaitUntilEvent("domcontentloaded")
+	ok(t, err)
+	equals(t, screenshots.WaitUntilDOMContentLoaded, event)
+
+	resourceType, err := screenshots.ParseResourceType("eventsource")
+	ok(t, err)
+	equals(t, screenshots.ResourceTypeEventSource, resourceType)
+
+	_, err = screenshots.ParseMediaType("tv")
+	errorred(t, err, "unknown media type \"tv\"")
+
+	responseType, err := screenshots.ParseResponseType("json")
+	ok(t, err)
+	equals(t, screenshots.ResponseTypeJSON, responseType)
+
+	contentFormat, err := screenshots.ParseContentFormat("markdown")
+	ok(t, err)
+	equals(t, screenshots.ContentFormatMarkdown, contentFormat)
+
+	acl, err := screenshots.ParseStorageACL("public-read")
+	ok(t, err)
+	equals(t, screenshots.StorageACLPublicRead, acl)
+
+	algorithm, err := screenshots.ParseFullPageAlgorithm("by_sections")
+	ok(t, err)
+	equals(t, screenshots.FullPageAlgorithmBySections, algorithm)
+
+	paper, err := screenshots.ParsePaperFormat("a4")
+	ok(t, err)
+	equals(t, screenshots.PaperA4, paper)
+
+	selectorAlgorithm, err := screenshots.ParseSelectorAlgorithm("at_least_one")
+	ok(t, err)
+	equals(t, screenshots.SelectorAlgorithmAtLeastOne, selectorAlgorithm)
+
+	_, err = screenshots.ParseStorageACL("")
+	errorred(t, err, "unknown storage ACL \"\"")
+
+	_, err = screenshots.ParsePaperFormat("A4")
+	errorred(t, err, "unknown paper format \"A4\"")
+}
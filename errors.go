@@ -0,0 +1,186 @@
+package gosdk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+// maxErrorBodySize limits how much of an error response is read.
+const maxErrorBodySize = 1 << 20
+
+// Error codes returned by the ScreenshotOne.com API.
+// See https://screenshotone.com/docs/errors/ for the full list.
+const (
+	ErrorCodeRequestNotValid                   = "request_not_valid"
+	ErrorCodeSelectorNotFound                  = "selector_not_found"
+	ErrorCodeNetworkError                      = "network_error"
+	ErrorCodeTimeoutError                      = "timeout_error"
+	ErrorCodeAccessKeyRequired                 = "access_key_required"
+	ErrorCodeAccessKeyInvalid                  = "access_key_invalid"
+	ErrorCodeSignatureIsRequired               = "signature_is_required"
+	ErrorCodeSignatureIsNotValid               = "signature_is_not_valid"
+	ErrorCodeScreenshotsLimitReached           = "screenshots_limit_reached"
+	ErrorCodeConcurrencyLimitReached           = "concurrency_limit_reached"
+	ErrorCodeTooManyRequests                   = "too_many_requests"
+	ErrorCodeHostReturnedError                 = "host_returned_error"
+	ErrorCodeNameNotResolved                   = "name_not_resolved"
+	ErrorCodeContentContainsSpecifiedString    = "content_contains_specified_string"
+	ErrorCodeContentNotContainsSpecifiedString = "content_not_contains_specified_string"
+	ErrorCodeScriptTriggersRedirect            = "script_triggers_redirect"
+	ErrorCodeInvalidStorageConfiguration       = "invalid_storage_configuration"
+	ErrorCodeStorageAccessDenied               = "storage_access_denied"
+	ErrorCodeStorageReturnedTransientError     = "storage_returned_transient_error"
+	ErrorCodeTemporaryUnavailable              = "temporary_unavailable"
+	ErrorCodeInternalApplicationError          = "internal_application_error"
+)
+
+// Sentinel errors matching API errors with errors.Is.
+var (
+	ErrRequestNotValid         = errors.New("request is not valid")
+	ErrSelectorNotFound        = errors.New("selector not found")
+	ErrNetworkError            = errors.New("network error")
+	ErrTimeout                 = errors.New("timeout")
+	ErrAccessKeyRequired       = errors.New("access key is required")
+	ErrInvalidAccessKey        = errors.New("access key is invalid")
+	ErrSignatureRequired       = errors.New("signature is required")
+	ErrInvalidSignature        = errors.New("signature is not valid")
+	ErrScreenshotsLimitReached = errors.New("screenshots limit reached")
+	ErrConcurrencyLimitReached = errors.New("concurrency limit reached")
+	ErrTooManyRequests         = errors.New("too many requests")
+	ErrHostReturnedError       = errors.New("host returned error")
+	ErrNameNotResolved         = errors.New("name not resolved")
+	ErrStorage                 = errors.New("storage error")
+	ErrTemporaryUnavailable    = errors.New("temporary unavailable")
+	ErrInternal                = errors.New("internal application error")
+)
+
+var errorCodeSentinels = map[string]error{
+	ErrorCodeRequestNotValid:               ErrRequestNotValid,
+	ErrorCodeSelectorNotFound:              ErrSelectorNotFound,
+	ErrorCodeNetworkError:                  ErrNetworkError,
+	ErrorCodeTimeoutError:                  ErrTimeout,
+	ErrorCodeAccessKeyRequired:             ErrAccessKeyRequired,
+	ErrorCodeAccessKeyInvalid:              ErrInvalidAccessKey,
+	ErrorCodeSignatureIsRequired:           ErrSignatureRequired,
+	ErrorCodeSignatureIsNotValid:           ErrInvalidSignature,
+	ErrorCodeScreenshotsLimitReached:       ErrScreenshotsLimitReached,
+	ErrorCodeConcurrencyLimitReached:       ErrConcurrencyLimitReached,
+	ErrorCodeTooManyRequests:               ErrTooManyRequests,
+	ErrorCodeHostReturnedError:             ErrHostReturnedError,
+	ErrorCodeNameNotResolved:               ErrNameNotResolved,
+	ErrorCodeInvalidStorageConfiguration:   ErrStorage,
+	ErrorCodeStorageAccessDenied:           ErrStorage,
+	ErrorCodeStorageReturnedTransientError: ErrStorage,
+	ErrorCodeTemporaryUnavailable:          ErrTemporaryUnavailable,
+	ErrorCodeInternalApplicationError:      ErrInternal,
+}
+
+// retryableErrorCodes are the error codes for which repeating the same request may succeed.
+var retryableErrorCodes = map[string]bool{
+	ErrorCodeNetworkError:                  true,
+	ErrorCodeTimeoutError:                  true,
+	ErrorCodeConcurrencyLimitReached:       true,
+	ErrorCodeTooManyRequests:               true,
+	ErrorCodeStorageReturnedTransientError: true,
+	ErrorCodeTemporaryUnavailable:          true,
+	ErrorCodeInternalApplicationError:      true,
+}
+
+// APIError is returned when the ScreenshotOne.com API responds with an unsuccessful status code.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response, zero for errors reported by webhooks.
+	StatusCode int
+	// Status is the HTTP status of the response, e.g. "400 Bad Request".
+	Status string
+	// Header contains the response headers.
+	Header http.Header
+	// IsSuccessful is always false for API errors and kept to mirror the response body.
+	IsSuccessful bool
+	// Code is the API error code, e.g. "selector_not_found". Empty if the body could not be parsed.
+	Code string
+	// Message is the human-readable error message.
+	Message string
+	// DocumentationURL points to the documentation for the error.
+	DocumentationURL string
+	// Body is the raw response body.
+	Body []byte
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	// errors reported by webhooks have no status
+	message := "the request failed"
+	if e.StatusCode != 0 {
+		message = fmt.Sprintf("the server returned a response: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	if e.Code != "" {
+		message += ": " + e.Code
+	}
+	if e.Message != "" {
+		message += ": " + e.Message
+	}
+
+	return message
+}
+
+// Is reports whether the error matches one of the sentinel errors, e.g. ErrSelectorNotFound.
+func (e *APIError) Is(target error) bool {
+	sentinel, ok := errorCodeSentinels[e.Code]
+
+	return ok && sentinel == target
+}
+
+// Retryable reports whether repeating the same request may succeed.
+// The error code takes precedence; without it, 429 and 5xx status codes are considered retryable.
+func (e *APIError) Retryable() bool {
+	if e.Code != "" {
+		return retryableErrorCodes[e.Code]
+	}
+
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= http.StatusInternalServerError
+}
+
+// newAPIError reads the response body and parses the API error from it.
+func newAPIError(response *http.Response) *APIError {
+	apiError := &APIError{
+		StatusCode: response.StatusCode,
+		Status:     response.Status,
+		Header:     response.Header,
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(response.Body, maxErrorBodySize))
+	if err != nil {
+		return apiError
+	}
+	apiError.Body = body
+
+	// the body is not always JSON, e.g. when a proxy returns the error
+	var errorBody struct {
+		IsSuccessful     bool   `json:"is_successful"`
+		ErrorCode        string `json:"error_code"`
+		ErrorMessage     string `json:"error_message"`
+		DocumentationURL string `json:"documentation_url"`
+	}
+	if err := json.Unmarshal(body, &errorBody); err == nil {
+		apiError.IsSuccessful = errorBody.IsSuccessful
+		apiError.Code = errorBody.ErrorCode
+		apiError.Message = errorBody.ErrorMessage
+		apiError.DocumentationURL = errorBody.DocumentationURL
+	}
+
+	return apiError
+}
+
+// IsRetryable reports whether err is an API error which may succeed when the request is repeated.
+func IsRetryable(err error) bool {
+	var apiError *APIError
+	if errors.As(err, &apiError) {
+		return apiError.Retryable()
+	}
+
+	return false
+}
@@ -0,0 +1,95 @@
+package gosdk_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	screenshots "github.com/screenshotone/gosdk"
+)
+
+func TestTakeReturnsAPIError(t *testing.T) {
+	mockClient := &http.Client{
+		Transport: &mockRoundTripper{
+			statusCode: http.StatusBadRequest,
+			body:       []byte(`{"is_successful":false,"error_code":"selector_not_found","error_message":"The selector is not found.","documentation_url":"https://screenshotone.com/docs/errors/selector-not-found/"}`),
+		},
+	}
+
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", mockClient)
+	ok(t, err)
+
+	options := screenshots.NewTakeOptions("https://example.com").Selector(".missing")
+	_, err = client.Take(context.Background(), options)
+	errorred(t, err, "selector_not_found: The selector is not found.")
+
+	var apiError *screenshots.APIError
+	equals(t, true, errors.As(err, &apiError))
+	equals(t, http.StatusBadRequest, apiError.StatusCode)
+	equals(t, screenshots.ErrorCodeSelectorNotFound, apiError.Code)
+	equals(t, "https://screenshotone.com/docs/errors/selector-not-found/", apiError.DocumentationURL)
+	equals(t, false, apiError.Retryable())
+	equals(t, true, errors.Is(err, screenshots.ErrSelectorNotFound))
+	equals(t, false, errors.Is(err, screenshots.ErrTimeout))
+}
+
+func TestAPIErrorRetryable(t *testing.T) {
+	testCases := []struct {
+		err       *screenshots.APIError
+		retryable bool
+	}{
+		{&screenshots.APIError{StatusCode: http.StatusInternalServerError, Code: screenshots.ErrorCodeNetworkError}, true},
+		{&screenshots.APIError{StatusCode: http.StatusInternalServerError, Code: screenshots.ErrorCodeTimeoutError}, true},
+		{&screenshots.APIError{StatusCode: http.StatusTooManyRequests, Code: screenshots.ErrorCodeConcurrencyLimitReached}, true},
+		{&screenshots.APIError{StatusCode: http.StatusBadRequest, Code: screenshots.ErrorCodeAccessKeyInvalid}, false},
+		{&screenshots.APIError{StatusCode: http.StatusBadGateway}, true},
+		{&screenshots.APIError{StatusCode: http.StatusTooManyRequests}, true},
+		{&screenshots.APIError{StatusCode: http.StatusNotFound}, false},
+	}
+
+	for _, testCase := range testCases {
+		equals(t, testCase.retryable, testCase.err.Retryable())
+		equals(t, testCase.retryable, screenshots.IsRetryable(testCase.err))
+	}
+}
+
+func TestTakeReturnsAPIErrorForNonJSONBody(t *testing.T) {
+	mockClient := &http.Client{
+		Transport: &mockRoundTripper{
+			statusCode: http.StatusBadGateway,
+			body:       []byte("<html>Bad Gateway</html>"),
+		},
+	}
+
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", mockClient)
+	ok(t, err)
+
+	_, err = client.Take(context.Background(), screenshots.NewTakeOptions("https://example.com"))
+	errorred(t, err, "the server returned a response: 502 Bad Gateway")
+
+	var apiError *screenshots.APIError
+	equals(t, true, errors.As(err, &apiError))
+	equals(t, "", apiError.Code)
+	equals(t, "<html>Bad Gateway</html>", string(apiError.Body))
+	equals(t, true, apiError.Retryable())
+}
+
+func TestAPIErrorMessageFromServer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"is_successful":false,"error_code":"selector_not_found","error_message":"The selector is not found."}`))
+	}))
+	defer server.Close()
+
+	client, err := screenshots.NewClient("test-key", "test-secret", screenshots.WithBaseURL(server.URL))
+	ok(t, err)
+
+	_, err = client.Take(context.Background(), screenshots.NewTakeOptions("https://example.com"))
+
+	var apiError *screenshots.APIError
+	equals(t, true, errors.As(err, &apiError))
+	equals(t, "400 Bad Request", apiError.Status)
+	equals(t, "the server returned a response: 400 Bad Request: selector_not_found: The selector is not found.", err.Error())
+}
@@ -0,0 +1,147 @@
+package gosdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+var (
+	booleanOptionSet = newStringSet(booleanOptions)
+	integerOptionSet = newIntegerOptionSet()
+	floatOptionSet   = newStringSet([]string{"geolocation_latitude", "geolocation_longitude"})
+)
+
+// MarshalJSON encodes the options as a JSON object with a field per option named as in the API, e.g. "full_page".
+// Boolean and numeric options are encoded as JSON booleans and numbers, list options as arrays.
+// It has a value receiver, so options stored in structs by value are encoded too.
+func (o TakeOptions) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonObject(o.query))
+}
+
+// UnmarshalJSON decodes the options from a JSON object produced by MarshalJSON.
+func (o *TakeOptions) UnmarshalJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var fields map[string]interface{}
+	if err := decoder.Decode(&fields); err != nil {
+		return fmt.Errorf("failed to decode options: %w", err)
+	}
+
+	query := url.Values{}
+	for name, field := range fields {
+		switch value := field.(type) {
+		case nil:
+			continue
+		case []interface{}:
+			for _, item := range value {
+				s, err := jsonScalarString(item)
+				if err != nil {
+					return fmt.Errorf("failed to decode option \"%s\": %w", name, err)
+				}
+				query.Add(name, s)
+			}
+		default:
+			s, err := jsonScalarString(value)
+			if err != nil {
+				return fmt.Errorf("failed to decode option \"%s\": %w", name, err)
+			}
+			query.Set(name, s)
+		}
+	}
+	o.query = query
+
+	return nil
+}
+
+// MarshalText encodes the options as a URL query string, e.g. for YAML or TOML configuration files.
+func (o TakeOptions) MarshalText() ([]byte, error) {
+	return []byte(o.query.Encode()), nil
+}
+
+// UnmarshalText decodes the options from a URL query string produced by MarshalText.
+func (o *TakeOptions) UnmarshalText(text []byte) error {
+	query, err := url.ParseQuery(string(text))
+	if err != nil {
+		return fmt.Errorf("failed to decode options: %w", err)
+	}
+	o.query = query
+
+	return nil
+}
+
+// jsonObject converts the query to a JSON object with typed values.
+// Values which do not match the type of the option are kept as strings.
+func jsonObject(query url.Values) map[string]interface{} {
+	object := make(map[string]interface{}, len(query))
+	for name, values := range query {
+		if len(values) == 0 {
+			continue
+		}
+
+		if listOptions[name] || len(values) > 1 {
+			items := make([]interface{}, 0, len(values))
+			for _, value := range values {
+				items = append(items, jsonValue(name, value))
+			}
+			object[name] = items
+		} else {
+			object[name] = jsonValue(name, values[0])
+		}
+	}
+
+	return object
+}
+
+func jsonValue(name, value string) interface{} {
+	switch {
+	case booleanOptionSet[name]:
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	case integerOptionSet[name]:
+		if i, err := strconv.Atoi(value); err == nil {
+			return i
+		}
+	case floatOptionSet[name]:
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return json.Number(strconv.FormatFloat(f, 'f', -1, 64))
+		}
+	}
+
+	return value
+}
+
+func jsonScalarString(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case json.Number:
+		return v.String(), nil
+	default:
+		return "", fmt.Errorf("unsupported value %v", value)
+	}
+}
+
+func newStringSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+
+	return set
+}
+
+func newIntegerOptionSet() map[string]bool {
+	set := make(map[string]bool, len(integerRanges))
+	for _, r := range integerRanges {
+		set[r.option] = true
+	}
+
+	return set
+}
@@ -0,0 +1,88 @@
+package gosdk_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	screenshots "github.com/screenshotone/gosdk"
+)
+
+func TestMarshalJSONEncodesOptions(t *testing.T) {
+	options := screenshots.NewTakeOptions("https://example.com").
+		Format(screenshots.FormatJPEG).
+		FullPage(true).
+		ImageQuality(80).
+		GeolocationLatitude(52.52).
+		Cookies("key=value", "key1=value1").
+		WaitUntil(screenshots.WaitUntilLoad)
+
+	data, err := json.Marshal(options)
+	ok(t, err)
+
+	equals(t, `{"cookies":["key=value","key1=value1"],"format":"jpeg","full_page":true,"geolocation_latitude":52.52,"image_quality":80,"url":"https://example.com","wait_until":["load"]}`, string(data))
+}
+
+func TestUnmarshalJSONRoundTrips(t *testing.T) {
+	options := screenshots.NewTakeWithHTML("<h1>Hello</h1>").
+		Format(screenshots.FormatPNG).
+		OmitBackground(true).
+		ViewportWidth(1280).
+		Headers("X-Header-1: val1", "X-Header-2: val2").
+		CacheKey("123")
+
+	data, err := json.Marshal(options)
+	ok(t, err)
+
+	decoded := screenshots.NewTakeDefaults()
+	ok(t, json.Unmarshal(data, decoded))
+
+	equals(t, mustGenerateUnsignedTakeURL(t, "test-key", options), mustGenerateUnsignedTakeURL(t, "test-key", decoded))
+	equals(t, "123", decoded.Get("cache_key"))
+}
+
+func TestUnmarshalJSONIntoStruct(t *testing.T) {
+	var config struct {
+		Name    string                   `json:"name"`
+		Options *screenshots.TakeOptions `json:"options"`
+	}
+
+	err := json.Unmarshal([]byte(`{"name":"homepage","options":{"url":"https://example.com","full_page":true,"delay":2,"block_resources":["font"]}}`), &config)
+	ok(t, err)
+
+	equals(t, "https://api.screenshotone.com/take?access_key=test-key&block_resources=font&delay=2&full_page=true&url=https%3A%2F%2Fexample.com", mustGenerateUnsignedTakeURL(t, "test-key", config.Options))
+}
+
+func TestMarshalStructWithOptionsValue(t *testing.T) {
+	config := struct {
+		Options screenshots.TakeOptions `json:"options"`
+		Text    screenshots.TakeOptions `json:"text"`
+	}{
+		Options: *screenshots.NewTakeOptions("https://example.com").FullPage(true),
+		Text:    *screenshots.NewTakeOptions("https://example.com").Delay(2),
+	}
+
+	data, err := json.Marshal(config)
+	ok(t, err)
+	equals(t, `{"options":{"full_page":true,"url":"https://example.com"},"text":{"delay":2,"url":"https://example.com"}}`, string(data))
+
+	text, err := config.Text.MarshalText()
+	ok(t, err)
+	equals(t, "delay=2&url=https%3A%2F%2Fexample.com", string(text))
+}
+
+func TestUnmarshalJSONRejectsObjects(t *testing.T) {
+	err := json.Unmarshal([]byte(`{"url":{"host":"example.com"}}`), screenshots.NewTakeDefaults())
+	errorred(t, err, "failed to decode option \"url\"")
+}
+
+func TestMarshalTextRoundTrips(t *testing.T) {
+	options := screenshots.NewTakeOptions("https://example.com").FullPage(true).Cookies("a=b", "c=d")
+
+	text, err := options.MarshalText()
+	ok(t, err)
+	equals(t, "cookies=a%3Db&cookies=c%3Dd&full_page=true&url=https%3A%2F%2Fexample.com", string(text))
+
+	decoded := screenshots.NewTakeDefaults()
+	ok(t, decoded.UnmarshalText(text))
+	equals(t, []string{"a=b", "c=d"}, decoded.Values("cookies"))
+}
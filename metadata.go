@@ -0,0 +1,174 @@
+package gosdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+// MetadataResult is the JSON response of the take method.
+// It never includes the screenshot itself, only its URL.
+type MetadataResult struct {
+	// ScreenshotURL is the URL of the screenshot, available when the Cache or Store options are used.
+	// It is empty otherwise, and the screenshot can't be obtained without taking it again.
+	ScreenshotURL string
+	// StoreLocation is the location of the screenshot in the storage when the Store option is used.
+	StoreLocation string
+	// Metadata contains the data requested with the Metadata* options.
+	Metadata *Metadata
+	// Result is the raw response, its Body is the JSON document.
+	Result *TakeResult
+}
+
+// Metadata contains the data requested with the Metadata* options, fields of not requested data are empty.
+type Metadata struct {
+	// ImageSize is the actual size of the screenshot, see MetadataImageSize.
+	ImageSize *ImageSize `json:"image_size,omitempty"`
+	// Fonts are the fonts used by the page, see MetadataFonts.
+	Fonts []string `json:"fonts,omitempty"`
+	// OpenGraph is the Open Graph metadata of the page, see MetadataOpenGraph.
+	OpenGraph *OpenGraph `json:"open_graph,omitempty"`
+	// PageTitle is the title of the page, see MetadataPageTitle.
+	PageTitle string `json:"page_title,omitempty"`
+	// HTTPResponseStatusCode is the status code of the page response, see MetadataHTTPResponseStatusCode.
+	HTTPResponseStatusCode int `json:"http_response_status_code,omitempty"`
+	// HTTPResponseHeaders are the headers of the page response, see MetadataHTTPResponseHeaders.
+	HTTPResponseHeaders map[string]string `json:"http_response_headers,omitempty"`
+	// Content describes where to download the content of the page, see MetadataContent.
+	Content *ContentMetadata `json:"content,omitempty"`
+	// Icon is the favicon of the page, see MetadataIcon.
+	Icon *Icon `json:"icon,omitempty"`
+}
+
+// ImageSize is the size of the screenshot (pixels).
+type ImageSize struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// OpenGraph is the Open Graph metadata of the page.
+type OpenGraph struct {
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Image       string `json:"image,omitempty"`
+	URL         string `json:"url,omitempty"`
+	Type        string `json:"type,omitempty"`
+	SiteName    string `json:"site_name,omitempty"`
+	Locale      string `json:"locale,omitempty"`
+}
+
+// ContentMetadata describes where to download the content of the page.
+type ContentMetadata struct {
+	// URL is the temporary URL of the content.
+	URL string `json:"url"`
+	// Expires is when the URL expires.
+	Expires string `json:"expires,omitempty"`
+}
+
+// Icon is the favicon of the page.
+type Icon struct {
+	URL  string `json:"url"`
+	Type string `json:"type,omitempty"`
+}
+
+// TakeWithMetadata takes screenshot with the JSON response type and decodes the requested metadata.
+// The JSON response does not contain the screenshot bytes, so TakeWithMetadata never returns them.
+// It includes the URL of the screenshot only when the Cache or Store options are used,
+// use Download with it to get the screenshot itself, or Take if only the bytes are needed.
+func (client *Client) TakeWithMetadata(ctx context.Context, options *TakeOptions) (*MetadataResult, error) {
+	response, result, err := client.takeJSON(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	metadataResult := &MetadataResult{
+		ScreenshotURL: response.screenshotURL(),
+		Metadata:      response.Metadata,
+		Result:        result,
+	}
+	if response.Store != nil {
+		metadataResult.StoreLocation = response.Store.Location
+	}
+	if metadataResult.Metadata == nil {
+		metadataResult.Metadata = &Metadata{}
+	}
+
+	return metadataResult, nil
+}
+
+// jsonResponse is the response of the take method with the JSON response type.
+type jsonResponse struct {
+	ScreenshotURL string `json:"screenshot_url"`
+	CacheURL      string `json:"cache_url"`
+	Store         *struct {
+		Location string `json:"location"`
+	} `json:"store"`
+	Metadata *Metadata    `json:"metadata"`
+	Vision   *visionReply `json:"vision"`
+}
+
+func (r *jsonResponse) screenshotURL() string {
+	if r.ScreenshotURL != "" {
+		return r.ScreenshotURL
+	}
+
+	return r.CacheURL
+}
+
+// takeJSON takes screenshot with the JSON response type and decodes the response.
+func (client *Client) takeJSON(ctx context.Context, options *TakeOptions) (*jsonResponse, *TakeResult, error) {
+	result, err := client.Take(ctx, options.Clone().ResponseType(ResponseTypeJSON))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	response := &jsonResponse{}
+	if err := json.Unmarshal(result.Body, response); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode the JSON response: %w", err)
+	}
+
+	return response, result, nil
+}
+
+// Download downloads the file by the URL returned by the API, e.g. the screenshot or the content URL.
+func (client *Client) Download(ctx context.Context, rawURL string) ([]byte, error) {
+	body, err := client.download(ctx, rawURL)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the downloaded data: %w", err)
+	}
+
+	return data, nil
+}
+
+// download requests the URL without the access key, the rate limiter and retries.
+func (client *Client) download(ctx context.Context, rawURL string) (io.ReadCloser, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to instantiate HTTP request: %w", err)
+	}
+	if client.userAgent != "" {
+		request.Header.Set("User-Agent", client.userAgent)
+	}
+
+	response, err := client.httpClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download: %w", err)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		response.Body.Close()
+
+		return nil, fmt.Errorf("failed to download: the server returned a response: %d %s", response.StatusCode, http.StatusText(response.StatusCode))
+	}
+
+	return response.Body, nil
+}
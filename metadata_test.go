@@ -0,0 +1,99 @@
+package gosdk_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	screenshots "github.com/screenshotone/gosdk"
+)
+
+func TestTakeWithMetadataDecodesMetadata(t *testing.T) {
+	roundTripper := &mockRoundTripper{
+		statusCode: http.StatusOK,
+		header:     http.Header{"Content-Type": []string{"application/json"}},
+		body: []byte(`{
+			"cache_url": "https://cache.screenshotone.com/abc",
+			"store": {"location": "https://bucket.s3.amazonaws.com/example.png"},
+			"metadata": {
+				"image_size": {"width": 1280, "height": 1024},
+				"fonts": ["Inter", "Roboto"],
+				"open_graph": {"title": "Example", "description": "Example Domain", "image": "https://example.com/og.png"},
+				"page_title": "Example Domain",
+				"http_response_status_code": 200,
+				"http_response_headers": {"content-type": "text/html"},
+				"content": {"url": "https://content.screenshotone.com/abc", "expires": "2024-01-01T00:00:00Z"},
+				"icon": {"url": "https://example.com/favicon.ico", "type": "image/x-icon"}
+			}
+		}`),
+	}
+
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: roundTripper})
+	ok(t, err)
+
+	options := screenshots.NewTakeOptions("https://example.com").Cache(true).MetadataPageTitle(true)
+	result, err := client.TakeWithMetadata(context.Background(), options)
+	ok(t, err)
+
+	equals(t, "json", roundTripper.request.URL.Query().Get("response_type"))
+	equals(t, false, options.Has("response_type"))
+
+	equals(t, "https://cache.screenshotone.com/abc", result.ScreenshotURL)
+	equals(t, "https://bucket.s3.amazonaws.com/example.png", result.StoreLocation)
+	equals(t, "json", result.Result.Format)
+	equals(t, &screenshots.Metadata{
+		ImageSize:              &screenshots.ImageSize{Width: 1280, Height: 1024},
+		Fonts:                  []string{"Inter", "Roboto"},
+		OpenGraph:              &screenshots.OpenGraph{Title: "Example", Description: "Example Domain", Image: "https://example.com/og.png"},
+		PageTitle:              "Example Domain",
+		HTTPResponseStatusCode: 200,
+		HTTPResponseHeaders:    map[string]string{"content-type": "text/html"},
+		Content:                &screenshots.ContentMetadata{URL: "https://content.screenshotone.com/abc", Expires: "2024-01-01T00:00:00Z"},
+		Icon:                   &screenshots.Icon{URL: "https://example.com/favicon.ico", Type: "image/x-icon"},
+	}, result.Metadata)
+}
+
+func TestTakeWithMetadataWithoutMetadata(t *testing.T) {
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: &mockRoundTripper{
+		statusCode: http.StatusOK,
+		body:       []byte(`{}`),
+	}})
+	ok(t, err)
+
+	result, err := client.TakeWithMetadata(context.Background(), screenshots.NewTakeOptions("https://example.com"))
+	ok(t, err)
+
+	equals(t, "", result.ScreenshotURL)
+	equals(t, &screenshots.Metadata{}, result.Metadata)
+}
+
+func TestTakeWithMetadataRejectsInvalidJSON(t *testing.T) {
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: &mockRoundTripper{
+		statusCode: http.StatusOK,
+		body:       []byte("test image data"),
+	}})
+	ok(t, err)
+
+	_, err = client.TakeWithMetadata(context.Background(), screenshots.NewTakeOptions("https://example.com"))
+	errorred(t, err, "failed to decode the JSON response")
+}
+
+func TestDownload(t *testing.T) {
+	roundTripper := &mockRoundTripper{statusCode: http.StatusOK, body: []byte("test image data")}
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: roundTripper})
+	ok(t, err)
+
+	data, err := client.Download(context.Background(), "https://cache.screenshotone.com/abc")
+	ok(t, err)
+
+	equals(t, "test image data", string(data))
+	equals(t, "https://cache.screenshotone.com/abc", roundTripper.request.URL.String())
+}
+
+func TestDownloadFailsOnErrorStatus(t *testing.T) {
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: &mockRoundTripper{statusCode: http.StatusNotFound}})
+	ok(t, err)
+
+	_, err = client.Download(context.Background(), "https://cache.screenshotone.com/abc")
+	errorred(t, err, "failed to download: the server returned a response: 404 Not Found")
+}
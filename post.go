@@ -0,0 +1,38 @@
+package gosdk
+
+import (
+	"encoding/json"
+	"net/url"
+)
+
+// defaultPOSTThreshold is the length of the encoded query above which take requests are sent as POST.
+// Most servers and proxies reject URLs longer than 8 KB.
+const defaultPOSTThreshold = 6 * 1024
+
+// WithPOSTThreshold sets the length of the encoded query above which Take sends the options
+// as a POST request with the JSON body instead of the GET query string.
+// Zero or a negative threshold disables switching to POST automatically.
+// The POST body is not signed, only the access key in it authenticates the request.
+func WithPOSTThreshold(threshold int) ClientOption {
+	return func(client *Client) error {
+		client.postThreshold = threshold
+
+		return nil
+	}
+}
+
+// WithAlwaysPOST makes Take always send the options as a POST request with the JSON body.
+// Useful for long HTML, Markdown, scripts or styles.
+func WithAlwaysPOST() ClientOption {
+	return func(client *Client) error {
+		client.alwaysPOST = true
+
+		return nil
+	}
+}
+
+// encodeJSONBody encodes the query as the JSON body of the take request
+// in the same format as TakeOptions.MarshalJSON.
+func encodeJSONBody(query url.Values) ([]byte, error) {
+	return json.Marshal(jsonObject(query))
+}
@@ -0,0 +1,85 @@
+package gosdk_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	screenshots "github.com/screenshotone/gosdk"
+)
+
+func TestTakeSendsPOSTForLargeOptions(t *testing.T) {
+	roundTripper := &mockRoundTripper{statusCode: http.StatusOK}
+
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: roundTripper},
+		screenshots.WithPOSTThreshold(100),
+	)
+	ok(t, err)
+
+	html := "<h1>" + strings.Repeat("Hello, world! ", 20) + "</h1>"
+	options := screenshots.NewTakeWithHTML(html).Format("png").Cookies("key=value").BlockResources("font", "image")
+	_, err = client.Take(context.Background(), options)
+	ok(t, err)
+
+	equals(t, http.MethodPost, roundTripper.request.Method)
+	equals(t, "/take", roundTripper.request.URL.Path)
+	equals(t, "", roundTripper.request.URL.RawQuery)
+	equals(t, "application/json", roundTripper.request.Header.Get("Content-Type"))
+
+	data, err := ioutil.ReadAll(roundTripper.request.Body)
+	ok(t, err)
+
+	var body map[string]interface{}
+	ok(t, json.Unmarshal(data, &body))
+	equals(t, map[string]interface{}{
+		"access_key":      "test-key",
+		"html":            html,
+		"format":          "png",
+		"cookies":         []interface{}{"key=value"},
+		"block_resources": []interface{}{"font", "image"},
+	}, body)
+}
+
+func TestTakeSendsGETForSmallOptions(t *testing.T) {
+	roundTripper := &mockRoundTripper{statusCode: http.StatusOK}
+
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: roundTripper})
+	ok(t, err)
+
+	_, err = client.Take(context.Background(), screenshots.NewTakeWithHTML("<h1>Hello, world!</h1>"))
+	ok(t, err)
+
+	equals(t, http.MethodGet, roundTripper.request.Method)
+	equals(t, "<h1>Hello, world!</h1>", roundTripper.request.URL.Query().Get("html"))
+	equals(t, true, roundTripper.request.URL.Query().Get("signature") != "")
+}
+
+func TestWithAlwaysPOSTSendsPOST(t *testing.T) {
+	roundTripper := &mockRoundTripper{statusCode: http.StatusOK}
+
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: roundTripper},
+		screenshots.WithAlwaysPOST(),
+	)
+	ok(t, err)
+
+	_, err = client.Take(context.Background(), screenshots.NewTakeOptions("https://example.com"))
+	ok(t, err)
+
+	equals(t, http.MethodPost, roundTripper.request.Method)
+}
+
+func TestPOSTRequiresSecretKey(t *testing.T) {
+	roundTripper := &mockRoundTripper{statusCode: http.StatusOK}
+
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "", &http.Client{Transport: roundTripper},
+		screenshots.WithAlwaysPOST(),
+	)
+	ok(t, err)
+
+	_, err = client.Take(context.Background(), screenshots.NewTakeOptions("https://example.com"))
+	errorred(t, err, "secret key is required")
+	equals(t, (*http.Request)(nil), roundTripper.request)
+}
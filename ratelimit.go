@@ -0,0 +1,160 @@
+package gosdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// defaultRateLimitPause is how long the limiter pauses requests after the 429 response without Retry-After.
+const defaultRateLimitPause = time.Second
+
+// RateLimiter limits the rate and the number of concurrent requests of clients.
+// It pauses all the requests when the API responds with 429 Too Many Requests,
+// for the duration of the Retry-After response header when present.
+//
+// A limiter is safe for concurrent use and can be shared by many clients using the same access key.
+type RateLimiter struct {
+	mu          sync.Mutex
+	rate        float64
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+
+	slots chan struct{}
+}
+
+// NewRateLimiter returns a limiter allowing requestsPerMinute requests per minute with at most
+// concurrency requests in flight. Set them to the limits of the plan. Zero or a negative value disables the limit.
+//
+// The limiter allows bursts of up to concurrency requests, or a single request if the concurrency is not limited.
+func NewRateLimiter(requestsPerMinute, concurrency int) *RateLimiter {
+	limiter := &RateLimiter{burst: 1}
+	if requestsPerMinute > 0 {
+		limiter.rate = float64(requestsPerMinute) / 60
+	}
+	if concurrency > 0 {
+		limiter.slots = make(chan struct{}, concurrency)
+		limiter.burst = float64(concurrency)
+	}
+	limiter.tokens = limiter.burst
+
+	return limiter
+}
+
+// WithRateLimiter makes the client wait for the limiter before sending every request.
+func WithRateLimiter(limiter *RateLimiter) ClientOption {
+	return func(client *Client) error {
+		if limiter == nil {
+			return fmt.Errorf("rate limiter is required")
+		}
+
+		client.rateLimiter = limiter
+
+		return nil
+	}
+}
+
+// wait waits until the request can be sent or the context is done.
+// The returned function must be called when the request is done to free its concurrency slot.
+func (l *RateLimiter) wait(ctx context.Context) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	release := func() {}
+	if l.slots != nil {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case l.slots <- struct{}{}:
+		}
+
+		var once sync.Once
+		release = func() {
+			once.Do(func() { <-l.slots })
+		}
+	}
+
+	for {
+		d := l.reserve(time.Now())
+		if d <= 0 {
+			return release, nil
+		}
+
+		if err := sleep(ctx, d); err != nil {
+			release()
+
+			return nil, err
+		}
+	}
+}
+
+// reserve takes a token and returns zero, or returns how long to wait before trying again.
+func (l *RateLimiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Before(l.pausedUntil) {
+		return l.pausedUntil.Sub(now)
+	}
+	if l.rate <= 0 {
+		return 0
+	}
+
+	if !l.last.IsZero() && now.After(l.last) {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = now
+
+	if l.tokens >= 1 {
+		l.tokens--
+
+		return 0
+	}
+
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+// observe pauses all the requests if the error is the 429 Too Many Requests response.
+func (l *RateLimiter) observe(err error) {
+	var apiError *APIError
+	if l == nil || !errors.As(err, &apiError) || apiError.StatusCode != http.StatusTooManyRequests {
+		return
+	}
+
+	now := time.Now()
+	pause, ok := parseRetryAfter(apiError.Header, now)
+	if !ok {
+		pause = defaultRateLimitPause
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if until := now.Add(pause); until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+	l.tokens = 0
+	l.last = l.pausedUntil
+}
+
+// releaseOnClose frees the concurrency slot of the request when the response body is closed.
+type releaseOnClose struct {
+	io.ReadCloser
+	release func()
+}
+
+func (r *releaseOnClose) Close() error {
+	defer r.release()
+
+	return r.ReadCloser.Close()
+}
@@ -0,0 +1,126 @@
+package gosdk_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	screenshots "github.com/screenshotone/gosdk"
+)
+
+func TestRateLimiterLimitsConcurrentRequests(t *testing.T) {
+	roundTripper := &inFlightRoundTripper{}
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: roundTripper},
+		screenshots.WithRateLimiter(screenshots.NewRateLimiter(0, 2)))
+	ok(t, err)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 6)
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			_, err := client.Take(context.Background(), screenshots.NewTakeOptions("https://example.com"))
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		ok(t, err)
+	}
+	equals(t, int32(2), atomic.LoadInt32(&roundTripper.max))
+}
+
+func TestRateLimiterLimitsRequestRate(t *testing.T) {
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: &urlEchoRoundTripper{}},
+		screenshots.WithRateLimiter(screenshots.NewRateLimiter(600, 0)))
+	ok(t, err)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		_, err := client.Take(context.Background(), screenshots.NewTakeOptions("https://example.com"))
+		ok(t, err)
+	}
+
+	// the first request is sent immediately, the next ones every 100ms
+	if elapsed := time.Since(start); elapsed < 190*time.Millisecond {
+		t.Fatalf("expected requests to be limited, but they took %s", elapsed)
+	}
+}
+
+func TestRateLimiterPausesAfterTooManyRequests(t *testing.T) {
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: &sequenceRoundTripper{
+		responses: []mockResponse{
+			{
+				statusCode: http.StatusTooManyRequests,
+				body:       `{"is_successful":false,"error_code":"too_many_requests"}`,
+				header:     http.Header{"Retry-After": []string{"1"}},
+			},
+			{statusCode: http.StatusOK, body: "test image data"},
+		},
+	}}, screenshots.WithRateLimiter(screenshots.NewRateLimiter(0, 0)))
+	ok(t, err)
+
+	_, err = client.Take(context.Background(), screenshots.NewTakeOptions("https://example.com"))
+	errorred(t, err, "too_many_requests")
+
+	start := time.Now()
+	result, err := client.Take(context.Background(), screenshots.NewTakeOptions("https://example.com"))
+	ok(t, err)
+	equals(t, "test image data", string(result.Body))
+
+	if elapsed := time.Since(start); elapsed < 900*time.Millisecond {
+		t.Fatalf("expected the request to wait for Retry-After, but it took %s", elapsed)
+	}
+}
+
+func TestRateLimiterHoldsSlotUntilBodyIsClosed(t *testing.T) {
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: &urlEchoRoundTripper{}},
+		screenshots.WithRateLimiter(screenshots.NewRateLimiter(0, 1)))
+	ok(t, err)
+
+	body, _, err := client.TakeStream(context.Background(), screenshots.NewTakeOptions("https://example.com"))
+	ok(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = client.Take(ctx, screenshots.NewTakeOptions("https://example.com"))
+	errorred(t, err, "failed to wait for the rate limiter: context deadline exceeded")
+
+	ok(t, body.Close())
+
+	_, err = client.Take(context.Background(), screenshots.NewTakeOptions("https://example.com"))
+	ok(t, err)
+}
+
+func TestWithRateLimiterRejectsNil(t *testing.T) {
+	_, err := screenshots.NewClient("test-key", "test-secret", screenshots.WithRateLimiter(nil))
+	errorred(t, err, "rate limiter is required")
+}
+
+// inFlightRoundTripper records the maximum number of concurrent requests, it is safe for concurrent use.
+type inFlightRoundTripper struct {
+	current, max int32
+}
+
+func (m *inFlightRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	current := atomic.AddInt32(&m.current, 1)
+	defer atomic.AddInt32(&m.current, -1)
+
+	for {
+		max := atomic.LoadInt32(&m.max)
+		if current <= max || atomic.CompareAndSwapInt32(&m.max, max, current) {
+			break
+		}
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	return (&urlEchoRoundTripper{}).RoundTrip(req)
+}
@@ -0,0 +1,98 @@
+package gosdk
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// metadataHeaderPrefix is the prefix of the response headers with rendering metadata.
+const metadataHeaderPrefix = "X-Screenshotone-"
+
+// TakeResult is the result of the ScreenshotOne.com API take method.
+type TakeResult struct {
+	// Body is the screenshot, rendered PDF, video or JSON response.
+	Body []byte
+	// ContentType is the MIME type of the body without parameters, e.g. "image/png".
+	ContentType string
+	// Format is the format detected from the content type, e.g. "png", "jpeg" or "pdf".
+	// Empty if the format is unknown.
+	Format string
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Header contains all the response headers.
+	Header http.Header
+	// Metadata contains the values of the "x-screenshotone-*" response headers
+	// keyed by the lowercased header name without the prefix, e.g. "cache-hit".
+	Metadata map[string]string
+	// Attempts is the number of requests made to get the result, greater than 1 if the request was retried.
+	// Zero if the result was served from the cache.
+	Attempts int
+	// Cached reports whether the result was served from the cache of the client, see WithCache.
+	Cached bool
+	// Quota contains the rate limits and the quota from the response headers, nil if the response has none.
+	Quota *Quota
+}
+
+func newTakeResult(response *http.Response, body []byte, attempts int) *TakeResult {
+	contentType := response.Header.Get("Content-Type")
+	if contentType == "" && len(body) > 0 {
+		contentType = http.DetectContentType(body)
+	}
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		contentType = mediaType
+	}
+
+	return &TakeResult{
+		Body:        body,
+		ContentType: contentType,
+		Format:      formatFromContentType(contentType),
+		StatusCode:  response.StatusCode,
+		Header:      response.Header,
+		Metadata:    parseMetadataHeaders(response.Header),
+		Attempts:    attempts,
+		Quota:       ParseQuota(response.Header),
+	}
+}
+
+// contentTypeFormats maps MIME types of responses to the API formats.
+var contentTypeFormats = map[string]string{
+	"image/png":        "png",
+	"image/jpeg":       "jpeg",
+	"image/webp":       "webp",
+	"image/gif":        "gif",
+	"image/avif":       "avif",
+	"image/heif":       "heif",
+	"image/tiff":       "tiff",
+	"image/jp2":        "jp2",
+	"application/pdf":  "pdf",
+	"text/html":        "html",
+	"text/markdown":    "markdown",
+	"application/json": "json",
+	"video/mp4":        "mp4",
+	"video/webm":       "webm",
+	"video/quicktime":  "mov",
+	"video/x-msvideo":  "avi",
+}
+
+func formatFromContentType(contentType string) string {
+	return contentTypeFormats[strings.ToLower(contentType)]
+}
+
+func parseMetadataHeaders(header http.Header) map[string]string {
+	metadata := make(map[string]string)
+	for name, values := range header {
+		canonical := http.CanonicalHeaderKey(name)
+		if !strings.HasPrefix(canonical, metadataHeaderPrefix) || len(values) == 0 {
+			continue
+		}
+
+		key := strings.ToLower(strings.TrimPrefix(canonical, metadataHeaderPrefix))
+		if key == "" {
+			continue
+		}
+		metadata[key] = values[0]
+	}
+
+	return metadata
+}
@@ -0,0 +1,174 @@
+package gosdk
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RetryPolicy controls how failed take requests are retried.
+// Only network errors and retryable API errors are retried, see APIError.Retryable.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts including the first one.
+	// Values less than 2 disable retries.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between attempts. Zero means no cap.
+	MaxBackoff time.Duration
+	// Multiplier is the factor the delay is multiplied by after every attempt. Values less than 1 are treated as 1.
+	Multiplier float64
+	// Jitter is the fraction of the delay randomly added or subtracted to spread retries, from 0 to 1.
+	Jitter float64
+	// RespectRetryAfter makes the delay equal to the Retry-After response header when present.
+	RespectRetryAfter bool
+	// ShouldRetry overrides which errors are retried. Nil uses the default classification.
+	ShouldRetry func(err error) bool
+}
+
+// DefaultRetryPolicy returns the recommended retry policy: up to 3 attempts with exponential backoff starting at 500ms.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:       3,
+		InitialBackoff:    500 * time.Millisecond,
+		MaxBackoff:        10 * time.Second,
+		Multiplier:        2,
+		Jitter:            0.2,
+		RespectRetryAfter: true,
+	}
+}
+
+// WithRetryPolicy enables retries of failed take requests with the given policy.
+func WithRetryPolicy(policy *RetryPolicy) ClientOption {
+	return func(client *Client) error {
+		client.retryPolicy = policy
+
+		return nil
+	}
+}
+
+// transportError marks errors returned by the HTTP client, they are retried by default.
+type transportError struct {
+	err error
+}
+
+func (e *transportError) Error() string {
+	return "failed to execute HTTP request: " + e.err.Error()
+}
+
+func (e *transportError) Unwrap() error {
+	return e.err
+}
+
+// retryable reports whether the attempt failed with err should be repeated.
+func (p *RetryPolicy) retryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if p.ShouldRetry != nil {
+		return p.ShouldRetry(err)
+	}
+
+	var te *transportError
+	if errors.As(err, &te) {
+		return true
+	}
+
+	return IsRetryable(err)
+}
+
+// delay returns how long to wait before the next attempt, attempt starts from 1.
+func (p *RetryPolicy) delay(attempt int, err error) time.Duration {
+	if p.RespectRetryAfter {
+		var apiError *APIError
+		if errors.As(err, &apiError) {
+			if retryAfter, ok := parseRetryAfter(apiError.Header, time.Now()); ok {
+				return retryAfter
+			}
+		}
+	}
+
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	backoff := float64(p.InitialBackoff)
+	for i := 1; i < attempt; i++ {
+		backoff *= multiplier
+		if p.MaxBackoff > 0 && backoff >= float64(p.MaxBackoff) {
+			break
+		}
+	}
+
+	if p.Jitter > 0 {
+		backoff += backoff * p.Jitter * (2*randomFloat64() - 1)
+	}
+	if p.MaxBackoff > 0 && backoff > float64(p.MaxBackoff) {
+		backoff = float64(p.MaxBackoff)
+	}
+	if backoff < 0 {
+		backoff = 0
+	}
+
+	return time.Duration(backoff)
+}
+
+// parseRetryAfter parses the Retry-After header given either in seconds or as an HTTP date.
+func parseRetryAfter(header http.Header, now time.Time) (time.Duration, bool) {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if date.Before(now) {
+			return 0, true
+		}
+
+		return date.Sub(now), true
+	}
+
+	return 0, false
+}
+
+// sleep waits for the duration or until the context is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+var (
+	randomMu     sync.Mutex
+	randomSource = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+func randomFloat64() float64 {
+	randomMu.Lock()
+	defer randomMu.Unlock()
+
+	return randomSource.Float64()
+}
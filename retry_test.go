@@ -0,0 +1,155 @@
+package gosdk_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	screenshots "github.com/screenshotone/gosdk"
+)
+
+func TestTakeRetriesRetryableErrors(t *testing.T) {
+	roundTripper := &sequenceRoundTripper{
+		responses: []mockResponse{
+			{err: errors.New("connection reset by peer")},
+			{statusCode: http.StatusInternalServerError, body: `{"is_successful":false,"error_code":"network_error"}`},
+			{statusCode: http.StatusOK, body: "test image data"},
+		},
+	}
+
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: roundTripper},
+		screenshots.WithRetryPolicy(&screenshots.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}),
+	)
+	ok(t, err)
+
+	result, err := client.Take(context.Background(), screenshots.NewTakeOptions("https://example.com"))
+	ok(t, err)
+
+	equals(t, "test image data", string(result.Body))
+	equals(t, 3, result.Attempts)
+	equals(t, 3, roundTripper.calls)
+}
+
+func TestTakeStopsRetryingAfterMaxAttempts(t *testing.T) {
+	roundTripper := &sequenceRoundTripper{
+		responses: []mockResponse{
+			{statusCode: http.StatusServiceUnavailable, body: `{"is_successful":false,"error_code":"temporary_unavailable"}`},
+			{statusCode: http.StatusServiceUnavailable, body: `{"is_successful":false,"error_code":"temporary_unavailable"}`},
+			{statusCode: http.StatusOK, body: "test image data"},
+		},
+	}
+
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: roundTripper},
+		screenshots.WithRetryPolicy(&screenshots.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond}),
+	)
+	ok(t, err)
+
+	_, err = client.Take(context.Background(), screenshots.NewTakeOptions("https://example.com"))
+	equals(t, true, errors.Is(err, screenshots.ErrTemporaryUnavailable))
+	equals(t, 2, roundTripper.calls)
+}
+
+func TestTakeDoesNotRetryNonRetryableErrors(t *testing.T) {
+	roundTripper := &sequenceRoundTripper{
+		responses: []mockResponse{
+			{statusCode: http.StatusBadRequest, body: `{"is_successful":false,"error_code":"selector_not_found"}`},
+			{statusCode: http.StatusOK, body: "test image data"},
+		},
+	}
+
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: roundTripper},
+		screenshots.WithRetryPolicy(screenshots.DefaultRetryPolicy()),
+	)
+	ok(t, err)
+
+	_, err = client.Take(context.Background(), screenshots.NewTakeOptions("https://example.com"))
+	equals(t, true, errors.Is(err, screenshots.ErrSelectorNotFound))
+	equals(t, 1, roundTripper.calls)
+}
+
+func TestTakeRespectsRetryAfter(t *testing.T) {
+	header := make(http.Header)
+	header.Set("Retry-After", "0")
+
+	roundTripper := &sequenceRoundTripper{
+		responses: []mockResponse{
+			{statusCode: http.StatusTooManyRequests, header: header},
+			{statusCode: http.StatusOK, body: "test image data"},
+		},
+	}
+
+	// the backoff is too long for the test to pass unless Retry-After is used
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: roundTripper},
+		screenshots.WithRetryPolicy(&screenshots.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Hour, RespectRetryAfter: true}),
+	)
+	ok(t, err)
+
+	result, err := client.Take(context.Background(), screenshots.NewTakeOptions("https://example.com"))
+	ok(t, err)
+
+	equals(t, 2, result.Attempts)
+}
+
+func TestTakeStopsRetryingWhenContextIsDone(t *testing.T) {
+	roundTripper := &sequenceRoundTripper{
+		responses: []mockResponse{
+			{statusCode: http.StatusServiceUnavailable},
+			{statusCode: http.StatusOK, body: "test image data"},
+		},
+	}
+
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: roundTripper},
+		screenshots.WithRetryPolicy(&screenshots.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Hour}),
+	)
+	ok(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = client.Take(ctx, screenshots.NewTakeOptions("https://example.com"))
+	equals(t, true, errors.Is(err, context.DeadlineExceeded))
+	equals(t, 1, roundTripper.calls)
+}
+
+type mockResponse struct {
+	statusCode int
+	body       string
+	header     http.Header
+	err        error
+}
+
+// sequenceRoundTripper returns the responses in order, repeating the last one.
+type sequenceRoundTripper struct {
+	responses []mockResponse
+	calls     int
+}
+
+func (m *sequenceRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	index := m.calls
+	if index >= len(m.responses) {
+		index = len(m.responses) - 1
+	}
+	m.calls++
+
+	response := m.responses[index]
+	if response.err != nil {
+		return nil, response.err
+	}
+
+	header := make(http.Header)
+	for name, values := range response.header {
+		header[name] = values
+	}
+
+	return &http.Response{
+		StatusCode: response.statusCode,
+		Status:     fmt.Sprintf("%d %s", response.statusCode, http.StatusText(response.statusCode)),
+		Body:       ioutil.NopCloser(bytes.NewReader([]byte(response.body))),
+		Header:     header,
+	}, nil
+}
@@ -0,0 +1,77 @@
+package gosdk
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// sign returns the hex-encoded HMAC-SHA256 signature of the query string.
+func sign(secretKey, queryString string) string {
+	hash := hmac.New(sha256.New, []byte(secretKey))
+	// writing to a hash never fails
+	_, _ = hash.Write([]byte(queryString))
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// VerifySignature verifies the signature of the raw query string of a signed take URL.
+// The signature is computed over the query string as it was sent, without the "signature" parameter,
+// so the order of the parameters matters.
+// It returns ErrSignatureRequired if the query is not signed and ErrInvalidSignature if the signature does not match.
+func VerifySignature(secretKey, rawQuery string) error {
+	if secretKey == "" {
+		return fmt.Errorf("secret key is required to verify signatures")
+	}
+
+	var signature string
+	var signed []string
+	for _, parameter := range strings.Split(rawQuery, "&") {
+		name, value := parameter, ""
+		if i := strings.Index(parameter, "="); i >= 0 {
+			name, value = parameter[:i], parameter[i+1:]
+		}
+		if name != "signature" {
+			signed = append(signed, parameter)
+
+			continue
+		}
+
+		var err error
+		signature, err = url.QueryUnescape(value)
+		if err != nil {
+			return fmt.Errorf("failed to parse the query string: %w", err)
+		}
+	}
+	if signature == "" {
+		return ErrSignatureRequired
+	}
+
+	expected := sign(secretKey, strings.Join(signed, "&"))
+	if !hmac.Equal([]byte(expected), []byte(strings.ToLower(signature))) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// VerifyTakeURL verifies that the take URL was signed with the secret key of the client
+// and generated for its access key, e.g. to reject tampered URLs before forwarding them.
+func (client *Client) VerifyTakeURL(u *url.URL) error {
+	if client.secretKey == "" {
+		return fmt.Errorf("secret key is required to verify signatures")
+	}
+
+	parsed, err := ParseTakeURL(u)
+	if err != nil {
+		return err
+	}
+	if parsed.AccessKey != client.accessKey {
+		return ErrInvalidAccessKey
+	}
+
+	return VerifySignature(client.secretKey, u.RawQuery)
+}
@@ -0,0 +1,79 @@
+package gosdk_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+
+	screenshots "github.com/screenshotone/gosdk"
+)
+
+func TestVerifyTakeURLAcceptsGeneratedURL(t *testing.T) {
+	client, err := screenshots.NewClient("IVmt2ghj9TG_jQ", "Sxt94yAj9aQSgg")
+	ok(t, err)
+
+	u, err := client.GenerateTakeURL(screenshots.NewTakeOptions("https://example.com").FullPage(true).Cookies("a=b", "c=d"))
+	ok(t, err)
+
+	ok(t, client.VerifyTakeURL(u))
+	ok(t, screenshots.VerifySignature("Sxt94yAj9aQSgg", u.RawQuery))
+}
+
+func TestVerifyTakeURLRejectsTamperedURL(t *testing.T) {
+	client, err := screenshots.NewClient("IVmt2ghj9TG_jQ", "Sxt94yAj9aQSgg")
+	ok(t, err)
+
+	u, err := url.Parse("https://api.screenshotone.com/take?access_key=IVmt2ghj9TG_jQ&block_ads=true&block_trackers=true&device_scale_factor=2&format=png&full_page=true&url=https%3A%2F%2Fscalabledeveloper.com&signature=85aabf7ac251563ec6158ef6839dd019bb79ce222cc85288a2e8cea0291a824e")
+	ok(t, err)
+	ok(t, client.VerifyTakeURL(u))
+
+	tampered := *u
+	tampered.RawQuery = "access_key=IVmt2ghj9TG_jQ&block_ads=true&block_trackers=true&device_scale_factor=3&format=png&full_page=true&url=https%3A%2F%2Fscalabledeveloper.com&signature=85aabf7ac251563ec6158ef6839dd019bb79ce222cc85288a2e8cea0291a824e"
+	equals(t, true, errors.Is(client.VerifyTakeURL(&tampered), screenshots.ErrInvalidSignature))
+
+	unsigned := *u
+	unsigned.RawQuery = "access_key=IVmt2ghj9TG_jQ&url=https%3A%2F%2Fscalabledeveloper.com"
+	equals(t, true, errors.Is(client.VerifyTakeURL(&unsigned), screenshots.ErrSignatureRequired))
+}
+
+func TestVerifyTakeURLRejectsOtherAccessKeys(t *testing.T) {
+	signer, err := screenshots.NewClient("other-key", "Sxt94yAj9aQSgg")
+	ok(t, err)
+
+	u, err := signer.GenerateTakeURL(screenshots.NewTakeOptions("https://example.com"))
+	ok(t, err)
+
+	client, err := screenshots.NewClient("IVmt2ghj9TG_jQ", "Sxt94yAj9aQSgg")
+	ok(t, err)
+
+	equals(t, true, errors.Is(client.VerifyTakeURL(u), screenshots.ErrInvalidAccessKey))
+}
+
+func TestVerifySignatureRequiresSecretKey(t *testing.T) {
+	err := screenshots.VerifySignature("", "access_key=test-key&signature=abc")
+	errorred(t, err, "secret key is required")
+}
+
+func TestVerifySignatureUsesRawQuery(t *testing.T) {
+	// signed over the query string as sent, which is not sorted
+	rawQuery := "url=https%3A%2F%2Fexample.com&access_key=test-key&format=png"
+	hash := hmac.New(sha256.New, []byte("test-secret"))
+	hash.Write([]byte(rawQuery))
+	ok(t, screenshots.VerifySignature("test-secret", rawQuery+"&signature="+hex.EncodeToString(hash.Sum(nil))))
+
+	client, err := screenshots.NewClient("test-key", "test-secret")
+	ok(t, err)
+
+	u, err := client.GenerateTakeURL(screenshots.NewTakeOptions("https://example.com").Format("png"))
+	ok(t, err)
+	ok(t, screenshots.VerifySignature("test-secret", u.RawQuery))
+
+	// reordering the signed parameters invalidates the signature
+	parameters := strings.Split(u.RawQuery, "&")
+	parameters[0], parameters[1] = parameters[1], parameters[0]
+	equals(t, screenshots.ErrInvalidSignature, screenshots.VerifySignature("test-secret", strings.Join(parameters, "&")))
+}
@@ -0,0 +1,40 @@
+package gosdk
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ParsedTakeURL is a take URL parsed by ParseTakeURL.
+type ParsedTakeURL struct {
+	// Options are the take options without the access key and the signature.
+	Options *TakeOptions
+	// AccessKey is the access key the URL was generated with.
+	AccessKey string
+	// Signature is the signature of the URL, empty for unsigned URLs.
+	Signature string
+}
+
+// ParseTakeURL parses a URL generated by GenerateTakeURL or GenerateUnsignedTakeURL,
+// e.g. to re-sign it with another key or to modify the options.
+func ParseTakeURL(u *url.URL) (*ParsedTakeURL, error) {
+	if !strings.HasSuffix(u.Path, takePath) {
+		return nil, fmt.Errorf("path \"%s\" is not a take path", u.Path)
+	}
+
+	query, err := url.ParseQuery(u.RawQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the query string: %w", err)
+	}
+
+	parsed := &ParsedTakeURL{
+		AccessKey: query.Get("access_key"),
+		Signature: query.Get("signature"),
+	}
+	query.Del("access_key")
+	query.Del("signature")
+	parsed.Options = &TakeOptions{query: query}
+
+	return parsed, nil
+}
@@ -0,0 +1,43 @@
+package gosdk_test
+
+import (
+	"net/url"
+	"testing"
+
+	screenshots "github.com/screenshotone/gosdk"
+)
+
+func TestParseTakeURLParsesSignedURL(t *testing.T) {
+	u, err := url.Parse("https://api.screenshotone.com/take?access_key=IVmt2ghj9TG_jQ&block_ads=true&block_trackers=true&device_scale_factor=2&format=png&full_page=true&url=https%3A%2F%2Fscalabledeveloper.com&signature=85aabf7ac251563ec6158ef6839dd019bb79ce222cc85288a2e8cea0291a824e")
+	ok(t, err)
+
+	parsed, err := screenshots.ParseTakeURL(u)
+	ok(t, err)
+
+	equals(t, "IVmt2ghj9TG_jQ", parsed.AccessKey)
+	equals(t, "85aabf7ac251563ec6158ef6839dd019bb79ce222cc85288a2e8cea0291a824e", parsed.Signature)
+	equals(t, false, parsed.Options.Has("access_key"))
+	equals(t, false, parsed.Options.Has("signature"))
+	equals(t, "https://scalabledeveloper.com", parsed.Options.Get("url"))
+
+	// re-signing with the same keys produces the same URL
+	client, err := screenshots.NewClient(parsed.AccessKey, "Sxt94yAj9aQSgg")
+	ok(t, err)
+
+	resigned, err := client.GenerateTakeURL(parsed.Options)
+	ok(t, err)
+	equals(t, u.String(), resigned.String())
+}
+
+func TestParseTakeURLRejectsOtherPaths(t *testing.T) {
+	u, err := url.Parse("https://api.screenshotone.com/usage?access_key=test-key")
+	ok(t, err)
+
+	_, err = screenshots.ParseTakeURL(u)
+	errorred(t, err, "path \"/usage\" is not a take path")
+}
+
+func TestParseTakeURLRejectsInvalidQuery(t *testing.T) {
+	_, err := screenshots.ParseTakeURL(&url.URL{Scheme: "https", Host: "api.screenshotone.com", Path: "/take", RawQuery: "url=%zz"})
+	errorred(t, err, "failed to parse the query string")
+}
@@ -0,0 +1,121 @@
+package gosdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const usagePath = "/usage"
+
+// Usage is the screenshots usage of the account in the current period.
+type Usage struct {
+	// Total is the number of screenshots available in the period.
+	Total int `json:"total"`
+	// Available is the number of screenshots left in the period.
+	Available int `json:"available"`
+	// Used is the number of screenshots taken in the period.
+	Used int `json:"used"`
+	// Concurrency describes the limit of concurrent requests.
+	Concurrency UsageConcurrency `json:"concurrency"`
+}
+
+// UsageConcurrency describes the limit of concurrent requests.
+type UsageConcurrency struct {
+	// Limit is the maximum number of concurrent requests.
+	Limit int `json:"limit"`
+	// Remaining is the number of requests which can be started now.
+	Remaining int `json:"remaining"`
+	// Reset is the Unix time the concurrency limit resets at.
+	Reset int64 `json:"reset"`
+}
+
+// Usage returns the screenshots usage of the account.
+func (client *Client) Usage(ctx context.Context) (*Usage, error) {
+	u, err := client.endpoint(usagePath)
+	if err != nil {
+		return nil, err
+	}
+	u.RawQuery = url.Values{"access_key": []string{client.accessKey}}.Encode()
+
+	response, _, err := client.execute(ctx, &apiRequest{method: http.MethodGet, url: u})
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	usage := &Usage{}
+	if err := json.NewDecoder(response.Body).Decode(usage); err != nil {
+		return nil, fmt.Errorf("failed to decode the usage response: %w", err)
+	}
+
+	return usage, nil
+}
+
+// Headers with the rate limits and the quota sent with API responses.
+const (
+	RateLimitLimitHeader       = "X-RateLimit-Limit"
+	RateLimitRemainingHeader   = "X-RateLimit-Remaining"
+	RateLimitResetHeader       = "X-RateLimit-Reset"
+	ConcurrencyLimitHeader     = "X-Concurrency-Limit"
+	ConcurrencyRemainingHeader = "X-Concurrency-Remaining"
+	QuotaLimitHeader           = "X-Quota-Limit"
+	QuotaRemainingHeader       = "X-Quota-Remaining"
+)
+
+// Quota contains the rate limits and the quota parsed from response headers.
+// Numeric fields are -1 when the corresponding header is missing.
+type Quota struct {
+	// RateLimit is the maximum number of requests in the rate limit window.
+	RateLimit int
+	// RateLimitRemaining is the number of requests left in the rate limit window.
+	RateLimitRemaining int
+	// RateLimitReset is the time the rate limit window resets at, zero if unknown.
+	RateLimitReset time.Time
+	// ConcurrencyLimit is the maximum number of concurrent requests.
+	ConcurrencyLimit int
+	// ConcurrencyRemaining is the number of requests which can be started now.
+	ConcurrencyRemaining int
+	// Limit is the number of screenshots available in the period.
+	Limit int
+	// Remaining is the number of screenshots left in the period.
+	Remaining int
+}
+
+// ParseQuota parses the rate limits and the quota from response headers.
+// It returns nil if none of the headers are present.
+func ParseQuota(header http.Header) *Quota {
+	found := false
+	parseInt := func(name string) int {
+		value, err := strconv.Atoi(header.Get(name))
+		if err != nil {
+			return -1
+		}
+		found = true
+
+		return value
+	}
+
+	quota := &Quota{
+		RateLimit:            parseInt(RateLimitLimitHeader),
+		RateLimitRemaining:   parseInt(RateLimitRemainingHeader),
+		ConcurrencyLimit:     parseInt(ConcurrencyLimitHeader),
+		ConcurrencyRemaining: parseInt(ConcurrencyRemainingHeader),
+		Limit:                parseInt(QuotaLimitHeader),
+		Remaining:            parseInt(QuotaRemainingHeader),
+	}
+	if reset, err := strconv.ParseInt(header.Get(RateLimitResetHeader), 10, 64); err == nil {
+		quota.RateLimitReset = time.Unix(reset, 0)
+		found = true
+	}
+
+	if !found {
+		return nil
+	}
+
+	return quota
+}
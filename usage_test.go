@@ -0,0 +1,73 @@
+package gosdk_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	screenshots "github.com/screenshotone/gosdk"
+)
+
+func TestUsageReturnsUsage(t *testing.T) {
+	roundTripper := &mockRoundTripper{
+		statusCode: http.StatusOK,
+		body:       []byte(`{"total":10000,"available":9000,"used":1000,"concurrency":{"limit":15,"remaining":14,"reset":1700000000}}`),
+	}
+
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: roundTripper})
+	ok(t, err)
+
+	usage, err := client.Usage(context.Background())
+	ok(t, err)
+
+	equals(t, "https://api.screenshotone.com/usage?access_key=test-key", roundTripper.request.URL.String())
+	equals(t, &screenshots.Usage{
+		Total:       10000,
+		Available:   9000,
+		Used:        1000,
+		Concurrency: screenshots.UsageConcurrency{Limit: 15, Remaining: 14, Reset: 1700000000},
+	}, usage)
+}
+
+func TestUsageRejectsInvalidAccessKey(t *testing.T) {
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: &mockRoundTripper{
+		statusCode: http.StatusUnauthorized,
+		body:       []byte(`{"is_successful":false,"error_code":"access_key_invalid"}`),
+	}})
+	ok(t, err)
+
+	_, err = client.Usage(context.Background())
+	errorred(t, err, "access_key_invalid")
+}
+
+func TestTakeParsesQuotaHeaders(t *testing.T) {
+	header := make(http.Header)
+	header.Set(screenshots.RateLimitLimitHeader, "40")
+	header.Set(screenshots.RateLimitRemainingHeader, "0")
+	header.Set(screenshots.RateLimitResetHeader, "1700000000")
+	header.Set(screenshots.QuotaRemainingHeader, "9000")
+
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: &mockRoundTripper{
+		statusCode: http.StatusOK,
+		header:     header,
+	}})
+	ok(t, err)
+
+	result, err := client.Take(context.Background(), screenshots.NewTakeOptions("https://example.com"))
+	ok(t, err)
+
+	equals(t, &screenshots.Quota{
+		RateLimit:            40,
+		RateLimitRemaining:   0,
+		RateLimitReset:       time.Unix(1700000000, 0),
+		ConcurrencyLimit:     -1,
+		ConcurrencyRemaining: -1,
+		Limit:                -1,
+		Remaining:            9000,
+	}, result.Quota)
+}
+
+func TestParseQuotaReturnsNilWithoutHeaders(t *testing.T) {
+	equals(t, (*screenshots.Quota)(nil), screenshots.ParseQuota(make(http.Header)))
+}
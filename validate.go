@@ -0,0 +1,308 @@
+package gosdk
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ValidationError describes an invalid option.
+type ValidationError struct {
+	// Option is the API name of the option, e.g. "image_quality".
+	Option string
+	// Message describes the problem.
+	Message string
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	return e.Option + ": " + e.Message
+}
+
+// ValidationErrors is returned when options are not valid. It lists all the problems found.
+type ValidationErrors []*ValidationError
+
+// Error implements the error interface.
+func (e ValidationErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+
+	return "invalid options: " + strings.Join(messages, "; ")
+}
+
+// WithValidation makes the client validate options before generating URLs or executing requests.
+func WithValidation() ClientOption {
+	return func(client *Client) error {
+		client.validate = true
+
+		return nil
+	}
+}
+
+// Validate checks the options for invalid values, unknown enum values,
+// mutually exclusive and dependent options without making any request.
+// It returns ValidationErrors or nil if the options are valid.
+func (o *TakeOptions) Validate() error {
+	return validateQuery(o.query)
+}
+
+var booleanOptions = []string{
+	"error_on_selector_not_found", "full_page", "omit_background", "block_ads", "block_trackers", "cache",
+	"capture_beyond_viewport", "full_page_scroll", "dark_mode", "reduced_motion", "viewport_mobile",
+	"viewport_has_touch", "viewport_landscape", "block_cookie_banners", "block_banners_by_heuristics",
+	"block_chats", "bypass_csp", "store", "storage_return_location", "async", "webhook_sign", "webhook_errors",
+	"request_gpu_rendering", "include_shadow_dom", "fail_if_gpu_rendering_fails", "metadata_image_size",
+	"metadata_fonts", "metadata_open_graph", "metadata_page_title", "metadata_http_response_headers",
+	"metadata_http_response_status_code", "metadata_content", "metadata_icon", "pdf_print_background",
+	"pdf_fit_one_page", "pdf_landscape", "selector_scroll_into_view", "ignore_host_errors",
+	"error_on_click_selector_not_found",
+}
+
+// integerRange is the range of values of an integer option.
+type integerRange struct {
+	option   string
+	min, max int
+}
+
+// enumOption lists the values of an option.
+type enumOption struct {
+	option string
+	values []string
+}
+
+// dependentOption is an option which is only valid when another option is set.
+type dependentOption struct {
+	option, requires string
+}
+
+var integerRanges = []integerRange{
+	{"image_quality", 0, 100},
+	{"image_width", 1, 1 << 16},
+	{"image_height", 1, 1 << 16},
+	{"viewport_width", 1, 1 << 16},
+	{"viewport_height", 1, 1 << 16},
+	{"device_scale_factor", 1, 3},
+	{"geolocation_accuracy", 0, math.MaxInt32},
+	{"cache_ttl", 0, math.MaxInt32},
+	{"delay", 0, math.MaxInt32},
+	{"timeout", 1, math.MaxInt32},
+	{"navigation_timeout", 1, math.MaxInt32},
+	{"full_page_scroll_delay", 0, math.MaxInt32},
+	{"full_page_scroll_by", 1, math.MaxInt32},
+	{"full_page_max_height", 1, math.MaxInt32},
+	{"scroll_into_view_adjust_top", math.MinInt32, math.MaxInt32},
+	{"vision_max_tokens", 1, math.MaxInt32},
+	{"clip_x", 0, math.MaxInt32},
+	{"clip_y", 0, math.MaxInt32},
+	{"clip_width", 1, math.MaxInt32},
+	{"clip_height", 1, math.MaxInt32},
+}
+
+var enumOptions = []enumOption{
+	{"format", formatValues},
+	{"scripts_wait_until", waitUntilValues},
+	{"wait_until", waitUntilValues},
+	{"block_resources", resourceTypeValues},
+	{"media_type", mediaTypeValues},
+	{"response_type", responseTypeValues},
+	{"metadata_content_format", contentFormatValues},
+	{"storage_acl", storageACLValues},
+	{"full_page_algorithm", fullPageAlgorithmValues},
+	{"pdf_paper_format", paperFormatValues},
+	{"selector_algorithm", selectorOptionAlgorithmValues},
+	{"wait_for_selector_algorithm", waitForSelectorAlgorithmValues},
+}
+
+// dependentOptions lists options which are only valid when another option is set.
+var dependentOptions = []dependentOption{
+	{"clip_x", "clip_width"},
+	{"clip_y", "clip_height"},
+	{"clip_width", "clip_height"},
+	{"clip_height", "clip_width"},
+	{"geolocation_latitude", "geolocation_longitude"},
+	{"geolocation_longitude", "geolocation_latitude"},
+	{"geolocation_accuracy", "geolocation_latitude"},
+	{"cache_ttl", "cache"},
+	{"cache_key", "cache"},
+	{"webhook_sign", "webhook_url"},
+	{"webhook_errors", "webhook_url"},
+	{"metadata_content_format", "metadata_content"},
+	{"vision_prompt", "openai_api_key"},
+	{"vision_max_tokens", "vision_prompt"},
+	{"wait_for_selector_algorithm", "wait_for_selector"},
+	{"selector_algorithm", "selector"},
+	{"scroll_into_view_adjust_top", "scroll_into_view"},
+}
+
+// validateQuery validates the query and returns ValidationErrors or nil.
+func validateQuery(query url.Values) error {
+	v := newValidator(query)
+	v.sources("url", "html", "markdown")
+	v.scalars()
+	v.booleans(booleanOptions)
+	v.integers(integerRanges)
+
+	for _, coordinate := range []struct {
+		option string
+		limit  float64
+	}{{"geolocation_latitude", 90}, {"geolocation_longitude", 180}} {
+		for _, value := range query[coordinate.option] {
+			number, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				v.addError(coordinate.option, "must be a number, got %q", value)
+				continue
+			}
+			if number < -coordinate.limit || number > coordinate.limit {
+				v.addError(coordinate.option, "must be between %v and %v, got %v", -coordinate.limit, coordinate.limit, number)
+			}
+		}
+	}
+
+	v.enums(enumOptions)
+	v.dependencies(dependentOptions)
+
+	// format-specific options
+	if format, ok := query["format"]; ok && len(format) > 0 {
+		if _, ok := query["image_quality"]; ok && !contains(qualityFormatValues, format[0]) {
+			v.addError("image_quality", "is only available for the %q formats, got %q", qualityFormatValues, format[0])
+		}
+		if query.Get("omit_background") == "true" && !contains(transparentFormatValues, format[0]) {
+			v.addError("omit_background", "is only available for the %q formats, got %q", transparentFormatValues, format[0])
+		}
+		if format[0] != "pdf" {
+			for _, option := range v.options {
+				if strings.HasPrefix(option, "pdf_") {
+					v.addError(option, "is only available for the \"pdf\" format, got %q", format[0])
+				}
+			}
+		}
+	}
+
+	return v.err()
+}
+
+// validator collects the problems found in the query.
+type validator struct {
+	query url.Values
+	// options are the names of the options set in the query, sorted
+	options []string
+	errs    ValidationErrors
+}
+
+func newValidator(query url.Values) *validator {
+	options := make([]string, 0, len(query))
+	for option := range query {
+		options = append(options, option)
+	}
+	sort.Strings(options)
+
+	return &validator{query: query, options: options}
+}
+
+func (v *validator) addError(option, format string, args ...interface{}) {
+	v.errs = append(v.errs, &ValidationError{Option: option, Message: fmt.Sprintf(format, args...)})
+}
+
+// sources checks that exactly one of the sources is set and the URL is absolute.
+func (v *validator) sources(sources ...string) {
+	var set []string
+	for _, option := range sources {
+		if _, ok := v.query[option]; ok {
+			set = append(set, option)
+		}
+	}
+	switch {
+	case len(set) == 0:
+		quoted := make([]string, 0, len(sources))
+		for _, option := range sources {
+			quoted = append(quoted, strconv.Quote(option))
+		}
+		v.addError(sources[0], "one of %s or %s is required", strings.Join(quoted[:len(quoted)-1], ", "), quoted[len(quoted)-1])
+	case len(set) > 1:
+		v.addError(set[1], "is mutually exclusive with %q", set[0])
+	}
+
+	if pageURL := v.query.Get("url"); v.query["url"] != nil {
+		u, err := url.Parse(pageURL)
+		if err != nil || !u.IsAbs() || u.Host == "" {
+			v.addError("url", "must be an absolute URL, got %q", pageURL)
+		}
+	}
+}
+
+// scalars checks that options other than the list ones are set only once.
+func (v *validator) scalars() {
+	for _, option := range v.options {
+		if values := v.query[option]; len(values) > 1 && !listOptions[option] {
+			v.addError(option, "must be set only once, got %d values", len(values))
+		}
+	}
+}
+
+func (v *validator) booleans(options []string) {
+	for _, option := range options {
+		for _, value := range v.query[option] {
+			if _, err := strconv.ParseBool(value); err != nil {
+				v.addError(option, "must be a boolean, got %q", value)
+			}
+		}
+	}
+}
+
+func (v *validator) integers(ranges []integerRange) {
+	for _, r := range ranges {
+		for _, value := range v.query[r.option] {
+			number, err := strconv.Atoi(value)
+			if err != nil {
+				v.addError(r.option, "must be an integer, got %q", value)
+				continue
+			}
+			if number < r.min || number > r.max {
+				v.addError(r.option, "must be between %d and %d, got %d", r.min, r.max, number)
+			}
+		}
+	}
+}
+
+func (v *validator) enums(enums []enumOption) {
+	for _, enum := range enums {
+		for _, value := range v.query[enum.option] {
+			if !contains(enum.values, value) {
+				v.addError(enum.option, "must be one of %q, got %q", enum.values, value)
+			}
+		}
+	}
+}
+
+func (v *validator) dependencies(dependencies []dependentOption) {
+	for _, dependency := range dependencies {
+		if _, ok := v.query[dependency.option]; ok {
+			if _, ok := v.query[dependency.requires]; !ok {
+				v.addError(dependency.option, "requires %q to be set", dependency.requires)
+			}
+		}
+	}
+}
+
+func (v *validator) err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+
+	return v.errs
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
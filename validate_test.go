@@ -0,0 +1,101 @@
+package gosdk_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	screenshots "github.com/screenshotone/gosdk"
+)
+
+func TestValidateAcceptsValidOptions(t *testing.T) {
+	options := screenshots.NewTakeOptions("https://example.com").
+		Format("jpg").
+		ImageQuality(80).
+		FullPage(true).
+		ViewportWidth(1280).
+		ViewportHeight(720).
+		DeviceScaleFactor(2).
+		WaitUntil("load", "networkidle0").
+		BlockResources("font", "image").
+		ClipX(0).ClipY(0).ClipWidth(100).ClipHeight(100)
+
+	ok(t, options.Validate())
+}
+
+func TestValidateReportsAllProblems(t *testing.T) {
+	options := screenshots.NewTakeOptions("https://example.com").
+		Format("bmp").
+		ImageQuality(-1).
+		WaitUntil("idle").
+		ClipX(10)
+
+	err := options.Validate()
+
+	var validationErrors screenshots.ValidationErrors
+	equals(t, true, errors.As(err, &validationErrors))
+
+	var problems []string
+	for _, validationError := range validationErrors {
+		problems = append(problems, validationError.Option)
+	}
+	equals(t, []string{"image_quality", "format", "wait_until", "clip_x", "image_quality"}, problems)
+	errorred(t, err, "image_quality: must be between 0 and 100, got -1")
+	errorred(t, err, "clip_x: requires \"clip_width\" to be set")
+}
+
+func TestValidateRejectsMutuallyExclusiveSources(t *testing.T) {
+	err := screenshots.NewTakeWithHTML("<h1>Hello</h1>").Validate()
+	ok(t, err)
+
+	err = screenshots.NewTakeDefaults().Validate()
+	errorred(t, err, "one of \"url\", \"html\" or \"markdown\" is required")
+
+	err = screenshots.NewTakeOptions("example.com").Validate()
+	errorred(t, err, "url: must be an absolute URL")
+}
+
+func TestValidateRejectsEmptyStorageACL(t *testing.T) {
+	err := screenshots.NewTakeOptions("https://example.com").StorageACL("").Validate()
+	errorred(t, err, "storage_acl: must be one of [\"public-read\"], got \"\"")
+}
+
+func TestValidateRejectsUnknownSelectorAlgorithms(t *testing.T) {
+	ok(t, screenshots.NewTakeOptions("https://example.com").
+		Selector("h1").SelectorAlgorithm(screenshots.SelectorAlgorithmCSS).
+		WaitForSelector("h1").WaitForSelectorAlgorithm(screenshots.SelectorAlgorithmAtLeastByCount).
+		Validate())
+
+	err := screenshots.NewTakeOptions("https://example.com").Selector("h1").SelectorAlgorithm("cs").Validate()
+	errorred(t, err, "selector_algorithm: must be one of [\"css\"], got \"cs\"")
+
+	err = screenshots.NewTakeOptions("https://example.com").WaitForSelector("h1").WaitForSelectorAlgorithm("all").Validate()
+	errorred(t, err, "wait_for_selector_algorithm: must be one of [\"at_least_one\" \"at_least_by_count\"], got \"all\"")
+}
+
+func TestValidateRejectsFormatSpecificOptions(t *testing.T) {
+	err := screenshots.NewTakeOptions("https://example.com").Format("png").PDFLandscape(true).PDFPaperFormat("a4").Validate()
+	errorred(t, err, "pdf_landscape: is only available for the \"pdf\" format, got \"png\"")
+	errorred(t, err, "pdf_paper_format: is only available for the \"pdf\" format")
+
+	ok(t, screenshots.NewTakeOptions("https://example.com").Format("pdf").PDFLandscape(true).PDFPaperFormat("a4").Validate())
+}
+
+func TestWithValidationRejectsInvalidOptions(t *testing.T) {
+	roundTripper := &mockRoundTripper{statusCode: http.StatusOK}
+
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: roundTripper},
+		screenshots.WithValidation(),
+	)
+	ok(t, err)
+
+	options := screenshots.NewTakeOptions("https://example.com").GeolocationLatitude(99.98765)
+
+	_, err = client.GenerateTakeURL(options)
+	errorred(t, err, "invalid options: geolocation_latitude: must be between -90 and 90")
+
+	_, err = client.Take(context.Background(), options)
+	errorred(t, err, "geolocation_latitude: must be between -90 and 90")
+	equals(t, (*http.Request)(nil), roundTripper.request)
+}
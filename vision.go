@@ -0,0 +1,174 @@
+package gosdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// VisionResult is the answer of the OpenAI vision model about the screenshot.
+type VisionResult struct {
+	// Answer is the text answer of the model.
+	Answer string
+	// Usage is the number of tokens used by the model.
+	Usage VisionUsage
+	// ScreenshotURL is the URL of the analyzed screenshot, available when the Cache or Store options are used.
+	ScreenshotURL string
+	// Result is the raw response, its Body is the JSON document.
+	Result *TakeResult
+}
+
+// VisionUsage is the number of tokens used by the vision model.
+type VisionUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// visionReply is the vision part of the JSON response.
+type visionReply struct {
+	Completion string      `json:"completion"`
+	Usage      VisionUsage `json:"usage"`
+}
+
+// Analyze takes screenshot and asks the OpenAI vision model the prompt about it.
+// The options or the default options of the client must have the OpenAIAPIKey set. Use VisionPromptWithSchema to get the answer
+// as JSON and VisionResult.Decode to decode it.
+func (client *Client) Analyze(ctx context.Context, options *TakeOptions, prompt string) (*VisionResult, error) {
+	if client.query(options).Get("openai_api_key") == "" {
+		return nil, fmt.Errorf("OpenAI API key is required, see TakeOptions.OpenAIAPIKey")
+	}
+	if prompt == "" {
+		return nil, fmt.Errorf("prompt is required")
+	}
+
+	response, result, err := client.takeJSON(ctx, options.Clone().VisionPrompt(prompt))
+	if err != nil {
+		return nil, err
+	}
+	if response.Vision == nil {
+		return nil, fmt.Errorf("the response has no vision answer")
+	}
+
+	return &VisionResult{
+		Answer:        response.Vision.Completion,
+		Usage:         response.Vision.Usage,
+		ScreenshotURL: response.screenshotURL(),
+		Result:        result,
+	}, nil
+}
+
+// Decode decodes the answer as JSON into v, ignoring a Markdown code fence around it.
+func (r *VisionResult) Decode(v interface{}) error {
+	answer := strings.TrimSpace(r.Answer)
+	if strings.HasPrefix(answer, "```") {
+		answer = strings.TrimPrefix(answer, "```json")
+		answer = strings.TrimPrefix(answer, "```")
+		answer = strings.TrimSuffix(answer, "```")
+	}
+
+	if err := json.Unmarshal([]byte(answer), v); err != nil {
+		return fmt.Errorf("failed to decode the vision answer: %w", err)
+	}
+
+	return nil
+}
+
+// VisionPromptWithSchema returns the prompt asking the model to answer with JSON
+// matching the schema of v, which must be a struct or a pointer to a struct.
+// Fields are named after their JSON tags, and the "description" tag describes a field to the model:
+//
+//	type Page struct {
+//		Title     string   `json:"title"`
+//		HasPrices bool     `json:"has_prices" description:"whether the page lists prices"`
+//		Links     []string `json:"links,omitempty"`
+//	}
+func VisionPromptWithSchema(prompt string, v interface{}) (string, error) {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return "", fmt.Errorf("struct is required, got %T", v)
+	}
+
+	schema, err := json.Marshal(jsonSchema(t, make(map[reflect.Type]bool)))
+	if err != nil {
+		return "", fmt.Errorf("failed to encode the schema: %w", err)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(prompt)
+	buf.WriteString("\n\nAnswer only with a JSON object matching the following JSON schema, without any other text:\n")
+	buf.Write(schema)
+
+	return buf.String(), nil
+}
+
+// jsonSchema describes the type in the JSON schema format.
+// Recursive structs are described as objects without properties where they recur.
+func jsonSchema(t reflect.Type, visiting map[reflect.Type]bool) map[string]interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": jsonSchema(t.Elem(), visiting)}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": jsonSchema(t.Elem(), visiting)}
+	case reflect.Struct:
+		if visiting[t] {
+			return map[string]interface{}{"type": "object"}
+		}
+		visiting[t] = true
+		defer delete(visiting, t)
+
+		properties := make(map[string]interface{})
+		required := []string{}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+
+			name := field.Name
+			omitempty := false
+			if tag, ok := field.Tag.Lookup("json"); ok {
+				parts := strings.Split(tag, ",")
+				if parts[0] == "-" {
+					continue
+				}
+				if parts[0] != "" {
+					name = parts[0]
+				}
+				omitempty = contains(parts[1:], "omitempty")
+			}
+
+			property := jsonSchema(field.Type, visiting)
+			if description := field.Tag.Get("description"); description != "" {
+				property["description"] = description
+			}
+			properties[name] = property
+			if !omitempty {
+				required = append(required, name)
+			}
+		}
+
+		return map[string]interface{}{"type": "object", "properties": properties, "required": required}
+	default:
+		return map[string]interface{}{}
+	}
+}
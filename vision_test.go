@@ -0,0 +1,108 @@
+package gosdk_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	screenshots "github.com/screenshotone/gosdk"
+)
+
+func TestAnalyzeReturnsVisionResult(t *testing.T) {
+	roundTripper := &mockRoundTripper{
+		statusCode: http.StatusOK,
+		body: []byte(`{
+			"cache_url": "https://cache.screenshotone.com/abc",
+			"vision": {
+				"completion": "The page shows a pricing table.",
+				"usage": {"prompt_tokens": 800, "completion_tokens": 7, "total_tokens": 807}
+			}
+		}`),
+	}
+
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: roundTripper})
+	ok(t, err)
+
+	options := screenshots.NewTakeOptions("https://example.com").OpenAIAPIKey("sk-test")
+	result, err := client.Analyze(context.Background(), options, "What does the page show?")
+	ok(t, err)
+
+	query := roundTripper.request.URL.Query()
+	equals(t, "What does the page show?", query.Get("vision_prompt"))
+	equals(t, "json", query.Get("response_type"))
+	equals(t, false, options.Has("vision_prompt"))
+
+	equals(t, "The page shows a pricing table.", result.Answer)
+	equals(t, screenshots.VisionUsage{PromptTokens: 800, CompletionTokens: 7, TotalTokens: 807}, result.Usage)
+	equals(t, "https://cache.screenshotone.com/abc", result.ScreenshotURL)
+}
+
+func TestAnalyzeRequiresOpenAIAPIKey(t *testing.T) {
+	client, err := screenshots.NewClient("test-key", "test-secret")
+	ok(t, err)
+
+	_, err = client.Analyze(context.Background(), screenshots.NewTakeOptions("https://example.com"), "What does the page show?")
+	errorred(t, err, "OpenAI API key is required")
+}
+
+func TestAnalyzeUsesDefaultOpenAIAPIKey(t *testing.T) {
+	roundTripper := &mockRoundTripper{
+		statusCode: http.StatusOK,
+		body:       []byte(`{"vision": {"completion": "A pricing table."}}`),
+	}
+
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: roundTripper},
+		screenshots.WithDefaultOptions(screenshots.NewTakeDefaults().OpenAIAPIKey("sk-test")),
+	)
+	ok(t, err)
+
+	result, err := client.Analyze(context.Background(), screenshots.NewTakeOptions("https://example.com"), "What does the page show?")
+	ok(t, err)
+
+	equals(t, "sk-test", roundTripper.request.URL.Query().Get("openai_api_key"))
+	equals(t, "A pricing table.", result.Answer)
+}
+
+func TestAnalyzeFailsWithoutVisionAnswer(t *testing.T) {
+	client, err := screenshots.NewClientWithHTTPClient("test-key", "test-secret", &http.Client{Transport: &mockRoundTripper{
+		statusCode: http.StatusOK,
+		body:       []byte(`{}`),
+	}})
+	ok(t, err)
+
+	options := screenshots.NewTakeOptions("https://example.com").OpenAIAPIKey("sk-test")
+	_, err = client.Analyze(context.Background(), options, "What does the page show?")
+	errorred(t, err, "the response has no vision answer")
+}
+
+type visionPage struct {
+	Title     string   `json:"title"`
+	HasPrices bool     `json:"has_prices" description:"whether the page lists prices"`
+	Links     []string `json:"links,omitempty"`
+	Rating    *float64 `json:"rating,omitempty"`
+	Ignored   string   `json:"-"`
+	Parent    *visionPage
+}
+
+func TestVisionResultDecode(t *testing.T) {
+	result := &screenshots.VisionResult{Answer: "```json\n{\"title\": \"Pricing\", \"has_prices\": true, \"links\": [\"/buy\"]}\n```"}
+
+	var page visionPage
+	ok(t, result.Decode(&page))
+	equals(t, visionPage{Title: "Pricing", HasPrices: true, Links: []string{"/buy"}}, page)
+
+	errorred(t, (&screenshots.VisionResult{Answer: "I can't tell."}).Decode(&page), "failed to decode the vision answer")
+}
+
+func TestVisionPromptWithSchema(t *testing.T) {
+	prompt, err := screenshots.VisionPromptWithSchema("Describe the page.", &visionPage{})
+	ok(t, err)
+
+	equals(t, "Describe the page.\n\nAnswer only with a JSON object matching the following JSON schema, without any other text:\n"+
+		`{"properties":{"Parent":{"type":"object"},"has_prices":{"description":"whether the page lists prices","type":"boolean"},`+
+		`"links":{"items":{"type":"string"},"type":"array"},"rating":{"type":"number"},"title":{"type":"string"}},`+
+		`"required":["title","has_prices","Parent"],"type":"object"}`, prompt)
+
+	_, err = screenshots.VisionPromptWithSchema("Describe the page.", "not a struct")
+	errorred(t, err, "struct is required, got string")
+}
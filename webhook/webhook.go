@@ -0,0 +1,267 @@
+// Package webhook receives and verifies webhook requests of the ScreenshotOne.com API
+// sent for requests with the WebhookURL option.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// SignatureHeader is the header with the hex-encoded HMAC-SHA256 signature of the request body.
+	SignatureHeader = "X-ScreenshotOne-Signature"
+	// TimestampHeader is the optional header with the Unix time the request was sent at.
+	// It is not covered by the signature, the age of requests is checked only when it is present.
+	TimestampHeader = "X-ScreenshotOne-Timestamp"
+)
+
+// DefaultTolerance is the maximum accepted age of a webhook request.
+const DefaultTolerance = 5 * time.Minute
+
+// maxBodySize limits the size of the webhook request body.
+const maxBodySize = 10 << 20
+
+var (
+	// ErrMissingSignature is returned when the request is not signed.
+	ErrMissingSignature = errors.New("webhook: signature is missing")
+	// ErrInvalidSignature is returned when the signature does not match the body.
+	ErrInvalidSignature = errors.New("webhook: signature is not valid")
+	// ErrMissingTimestamp is returned when the timestamp is required but missing.
+	ErrMissingTimestamp = errors.New("webhook: timestamp is missing")
+	// ErrExpired is returned when the request timestamp is outside of the tolerance.
+	ErrExpired = errors.New("webhook: request timestamp is outside of the tolerance")
+)
+
+// Event is the payload of a webhook request.
+type Event struct {
+	// IsSuccessful reports whether the screenshot was rendered.
+	IsSuccessful bool `json:"is_successful"`
+	// ExternalIdentifier is the value of the ExternalIdentifier option of the request.
+	ExternalIdentifier string `json:"external_identifier,omitempty"`
+	// ScreenshotURL is the URL of the rendered screenshot when available.
+	ScreenshotURL string `json:"screenshot_url,omitempty"`
+	// Store is the storage location when the Store option is used.
+	Store *StorageLocation `json:"store,omitempty"`
+	// ErrorCode is the API error code for failed requests, e.g. "selector_not_found".
+	ErrorCode string `json:"error_code,omitempty"`
+	// ErrorMessage is the human-readable error message for failed requests.
+	ErrorMessage string `json:"error_message,omitempty"`
+	// DocumentationURL points to the documentation for the error.
+	DocumentationURL string `json:"documentation_url,omitempty"`
+
+	// Raw is the raw request body.
+	Raw json.RawMessage `json:"-"`
+}
+
+// StorageLocation describes where the screenshot was stored.
+type StorageLocation struct {
+	// Location is the URL of the stored screenshot.
+	Location string `json:"location"`
+}
+
+// Callback handles a verified webhook event. Returning an error responds with 500, so the API may retry the request.
+type Callback func(ctx context.Context, event *Event) error
+
+// Handler is an http.Handler which verifies webhook requests and dispatches them to callbacks.
+type Handler struct {
+	secretKey        string
+	tolerance        time.Duration
+	requireTimestamp bool
+	now              func() time.Time
+
+	onSuccess Callback
+	onError   Callback
+}
+
+// Option configures the handler.
+type Option func(*Handler) error
+
+// NewHandler returns a webhook handler verifying requests with the secret key of the access key used for the requests.
+func NewHandler(secretKey string, opts ...Option) (*Handler, error) {
+	if secretKey == "" {
+		return nil, fmt.Errorf("secret key is required")
+	}
+
+	handler := &Handler{
+		secretKey: secretKey,
+		tolerance: DefaultTolerance,
+		now:       time.Now,
+	}
+
+	for _, opt := range opts {
+		if err := opt(handler); err != nil {
+			return nil, err
+		}
+	}
+
+	return handler, nil
+}
+
+// WithTolerance sets the maximum accepted difference between the request timestamp and the current time.
+// Zero disables the age check.
+func WithTolerance(tolerance time.Duration) Option {
+	return func(handler *Handler) error {
+		if tolerance < 0 {
+			return fmt.Errorf("tolerance must not be negative")
+		}
+
+		handler.tolerance = tolerance
+
+		return nil
+	}
+}
+
+// WithRequiredTimestamp rejects requests without the timestamp header.
+// Use it only if the requests are known to carry the header.
+func WithRequiredTimestamp() Option {
+	return func(handler *Handler) error {
+		handler.requireTimestamp = true
+
+		return nil
+	}
+}
+
+// WithClock sets the function returning the current time, useful in tests.
+func WithClock(now func() time.Time) Option {
+	return func(handler *Handler) error {
+		handler.now = now
+
+		return nil
+	}
+}
+
+// OnSuccess sets the callback for successfully rendered screenshots.
+func OnSuccess(callback Callback) Option {
+	return func(handler *Handler) error {
+		handler.onSuccess = callback
+
+		return nil
+	}
+}
+
+// OnError sets the callback for failed requests. It requires the WebhookErrors option on the request.
+func OnError(callback Callback) Option {
+	return func(handler *Handler) error {
+		handler.onError = callback
+
+		return nil
+	}
+}
+
+// ServeHTTP verifies the webhook request and dispatches the event to the callbacks.
+func (handler *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	event, err := handler.Parse(r)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, ErrMissingSignature) || errors.Is(err, ErrInvalidSignature) ||
+			errors.Is(err, ErrMissingTimestamp) || errors.Is(err, ErrExpired) {
+			status = http.StatusUnauthorized
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	callback := handler.onSuccess
+	if !event.IsSuccessful {
+		callback = handler.onError
+	}
+	if callback != nil {
+		if err := callback(r.Context(), event); err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// Parse reads the webhook request, verifies its signature and timestamp and decodes the event.
+func (handler *Handler) Parse(r *http.Request) (*Event, error) {
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxBodySize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the request body: %w", err)
+	}
+
+	if err := VerifySignature(handler.secretKey, body, r.Header.Get(SignatureHeader)); err != nil {
+		return nil, err
+	}
+
+	if err := handler.verifyTimestamp(r.Header.Get(TimestampHeader)); err != nil {
+		return nil, err
+	}
+
+	event := &Event{}
+	if err := json.NewDecoder(bytes.NewReader(body)).Decode(event); err != nil {
+		return nil, fmt.Errorf("failed to decode the request body: %w", err)
+	}
+	event.Raw = body
+
+	return event, nil
+}
+
+// verifyTimestamp rejects requests sent outside of the tolerance.
+// Requests without the timestamp header are accepted unless WithRequiredTimestamp is used.
+func (handler *Handler) verifyTimestamp(value string) error {
+	if value == "" {
+		if handler.requireTimestamp {
+			return ErrMissingTimestamp
+		}
+
+		return nil
+	}
+	if handler.tolerance == 0 {
+		return nil
+	}
+
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return fmt.Errorf("failed to parse the timestamp \"%s\": %w", value, err)
+	}
+
+	age := handler.now().Sub(time.Unix(seconds, 0))
+	if age > handler.tolerance || age < -handler.tolerance {
+		return ErrExpired
+	}
+
+	return nil
+}
+
+// Sign returns the hex-encoded HMAC-SHA256 signature of the body.
+func Sign(secretKey string, body []byte) string {
+	hash := hmac.New(sha256.New, []byte(secretKey))
+	// writing to a hash never fails
+	_, _ = hash.Write(body)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// VerifySignature verifies the hex-encoded HMAC-SHA256 signature of the body in constant time.
+func VerifySignature(secretKey string, body []byte, signature string) error {
+	if signature == "" {
+		return ErrMissingSignature
+	}
+
+	if !hmac.Equal([]byte(Sign(secretKey, body)), []byte(strings.ToLower(signature))) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
@@ -0,0 +1,167 @@
+package webhook_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/screenshotone/gosdk/webhook"
+)
+
+const secretKey = "Sxt94yAj9aQSgg"
+
+func TestHandlerDispatchesSuccessEvent(t *testing.T) {
+	var received *webhook.Event
+	handler, err := webhook.NewHandler(secretKey, webhook.OnSuccess(func(ctx context.Context, event *webhook.Event) error {
+		received = event
+		return nil
+	}))
+	ok(t, err)
+
+	body := `{"is_successful":true,"external_identifier":"job-1","store":{"location":"https://bucket.example.com/job-1.png"}}`
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, newRequest(body, webhook.Sign(secretKey, []byte(body)), time.Now()))
+
+	equals(t, http.StatusOK, recorder.Code)
+	equals(t, true, received.IsSuccessful)
+	equals(t, "job-1", received.ExternalIdentifier)
+	equals(t, "https://bucket.example.com/job-1.png", received.Store.Location)
+	equals(t, body, string(received.Raw))
+}
+
+func TestHandlerDispatchesErrorEvent(t *testing.T) {
+	var received *webhook.Event
+	handler, err := webhook.NewHandler(secretKey,
+		webhook.OnSuccess(func(ctx context.Context, event *webhook.Event) error {
+			t.Error("unexpected success event")
+			return nil
+		}),
+		webhook.OnError(func(ctx context.Context, event *webhook.Event) error {
+			received = event
+			return nil
+		}),
+	)
+	ok(t, err)
+
+	body := `{"is_successful":false,"external_identifier":"job-2","error_code":"selector_not_found","error_message":"The selector is not found."}`
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, newRequest(body, webhook.Sign(secretKey, []byte(body)), time.Now()))
+
+	equals(t, http.StatusOK, recorder.Code)
+	equals(t, "selector_not_found", received.ErrorCode)
+	equals(t, "The selector is not found.", received.ErrorMessage)
+}
+
+func TestHandlerRejectsInvalidRequests(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	body := `{"is_successful":true}`
+
+	handler, err := webhook.NewHandler(secretKey, webhook.WithClock(func() time.Time { return now }))
+	ok(t, err)
+
+	testCases := []struct {
+		request *http.Request
+		status  int
+		message string
+	}{
+		{newRequest(body, "", now), http.StatusUnauthorized, "signature is missing"},
+		{newRequest(body, webhook.Sign("other-secret", []byte(body)), now), http.StatusUnauthorized, "signature is not valid"},
+		{newRequest(body, webhook.Sign(secretKey, []byte(body)), now.Add(-10*time.Minute)), http.StatusUnauthorized, "outside of the tolerance"},
+		{newRequest("not json", webhook.Sign(secretKey, []byte("not json")), now), http.StatusBadRequest, "failed to decode the request body"},
+		{httptest.NewRequest(http.MethodGet, "/webhook", nil), http.StatusMethodNotAllowed, "Method Not Allowed"},
+	}
+
+	for _, testCase := range testCases {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, testCase.request)
+
+		equals(t, testCase.status, recorder.Code)
+		equals(t, true, strings.Contains(recorder.Body.String(), testCase.message))
+	}
+}
+
+func TestHandlerRequiresTimestamp(t *testing.T) {
+	handler, err := webhook.NewHandler(secretKey, webhook.WithRequiredTimestamp())
+	ok(t, err)
+
+	body := `{"is_successful":true}`
+	request := newRequest(body, webhook.Sign(secretKey, []byte(body)), time.Now())
+	request.Header.Del(webhook.TimestampHeader)
+
+	_, err = handler.Parse(request)
+	equals(t, true, errors.Is(err, webhook.ErrMissingTimestamp))
+
+	// the requirement does not depend on the tolerance
+	handler, err = webhook.NewHandler(secretKey, webhook.WithRequiredTimestamp(), webhook.WithTolerance(0))
+	ok(t, err)
+
+	request = newRequest(body, webhook.Sign(secretKey, []byte(body)), time.Now())
+	request.Header.Del(webhook.TimestampHeader)
+
+	_, err = handler.Parse(request)
+	equals(t, true, errors.Is(err, webhook.ErrMissingTimestamp))
+}
+
+func TestHandlerAcceptsRequestsWithoutTimestamp(t *testing.T) {
+	handler, err := webhook.NewHandler(secretKey)
+	ok(t, err)
+
+	// the API signs only the body and the timestamp header is optional
+	body := `{"is_successful":true,"external_identifier":"job-1"}`
+	request := newRequest(body, webhook.Sign(secretKey, []byte(body)), time.Now())
+	request.Header.Del(webhook.TimestampHeader)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	equals(t, http.StatusOK, recorder.Code)
+}
+
+func TestHandlerRespondsWithErrorWhenCallbackFails(t *testing.T) {
+	handler, err := webhook.NewHandler(secretKey, webhook.OnSuccess(func(ctx context.Context, event *webhook.Event) error {
+		return errors.New("database is down")
+	}))
+	ok(t, err)
+
+	body := `{"is_successful":true}`
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, newRequest(body, webhook.Sign(secretKey, []byte(body)), time.Now()))
+
+	equals(t, http.StatusInternalServerError, recorder.Code)
+}
+
+func newRequest(body, signature string, timestamp time.Time) *http.Request {
+	request := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	if signature != "" {
+		request.Header.Set(webhook.SignatureHeader, signature)
+	}
+	request.Header.Set(webhook.TimestampHeader, strconv.FormatInt(timestamp.Unix(), 10))
+
+	return request
+}
+
+// ok fails the test if an err is not nil.
+func ok(tb testing.TB, err error) {
+	if err != nil {
+		_, file, line, _ := runtime.Caller(1)
+		fmt.Printf("\033[31m%s:%d: unexpected error: %s\033[39m\n\n", filepath.Base(file), line, err.Error())
+		tb.FailNow()
+	}
+}
+
+// equals fails the test if exp is not equal to act.
+func equals(tb testing.TB, exp, act interface{}) {
+	if !reflect.DeepEqual(exp, act) {
+		_, file, line, _ := runtime.Caller(1)
+		fmt.Printf("\033[31m%s:%d:\n\n\texp: %#v\n\n\tgot: %#v\033[39m\n\n", filepath.Base(file), line, exp, act)
+		tb.FailNow()
+	}
+}